@@ -0,0 +1,195 @@
+// Package hdwallet derives keypairs from a BIP39 mnemonic at any account and address
+// index, and discovers the accounts of a wallet that have been used.
+//
+// The paths follow the ones used by the wallets of the network:
+//
+//	Ed25519    m/44'/938'/{account}'/0'/{address}'
+//	Secp256k1  m/54'/938'/{account}'/0/{address}
+//	Secp256r1  m/74'/938'/{account}'/0/{address}
+package hdwallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/account/signer/ed25519"
+	"github.com/mangonet-labs/mgo-go-sdk/account/signer/secp256k1"
+	"github.com/mangonet-labs/mgo-go-sdk/account/signer/secp256r1"
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model/filter"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultGapLimit is the number of consecutive unused accounts after which Discover stops.
+const DefaultGapLimit = 20
+
+// hardenedOffset is the first hardened child index. Account and address indices must be
+// below it, since a path segment only holds the index before the hardened marker.
+const hardenedOffset = 1 << 31
+
+var (
+	ErrInvalidPath   = errors.New("invalid derivation path")
+	ErrInvalidScheme = errors.New("invalid signature scheme flag")
+)
+
+// Wallet derives keypairs from the seed of a mnemonic.
+type Wallet struct {
+	seed []byte
+}
+
+// Account is a keypair derived by a Wallet.
+type Account struct {
+	AccountIndex uint32
+	AddressIndex uint32
+	Path         string
+	Keypair      *keypair.Keypair
+}
+
+// New returns the wallet of mnemonic, protected by the BIP39 passphrase, which may be
+// empty. The same mnemonic with another passphrase is a different wallet.
+func New(mnemonic string, passphrase string) (*Wallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithSeed(seed), nil
+}
+
+// NewWithSeed returns the wallet of a BIP39 seed.
+func NewWithSeed(seed []byte) *Wallet {
+	return &Wallet{seed: append([]byte(nil), seed...)}
+}
+
+// Path returns the derivation path of scheme at the account and address index. Indices
+// of 2^31 or more are rejected with ErrInvalidPath.
+func Path(scheme config.Scheme, account, address uint32) (string, error) {
+	if account >= hardenedOffset || address >= hardenedOffset {
+		return "", fmt.Errorf("%w: account %d, address %d: indices must be below 2^31", ErrInvalidPath, account, address)
+	}
+	switch scheme {
+	case config.Ed25519Flag:
+		return fmt.Sprintf("m/44'/938'/%d'/0'/%d'", account, address), nil
+	case config.Secp256k1Flag:
+		return fmt.Sprintf("m/54'/938'/%d'/0/%d", account, address), nil
+	case config.Secp256r1Flag:
+		return fmt.Sprintf("m/74'/938'/%d'/0/%d", account, address), nil
+	default:
+		return "", fmt.Errorf("%w: %d", ErrInvalidScheme, scheme)
+	}
+}
+
+// ValidatePath checks that path is a valid derivation path for scheme: its purpose is
+// the one of scheme and every index is below 2^31. Ed25519 paths must be fully hardened.
+func ValidatePath(scheme config.Scheme, path string) error {
+	var valid bool
+	var purpose uint64
+	switch scheme {
+	case config.Ed25519Flag:
+		valid, purpose = ed25519.IsValidPath(path), 44
+	case config.Secp256k1Flag:
+		valid, purpose = secp256k1.IsValidPath(path), 54
+	case config.Secp256r1Flag:
+		valid, purpose = secp256r1.IsValidPath(path), 74
+	default:
+		return fmt.Errorf("%w: %d", ErrInvalidScheme, scheme)
+	}
+	if !valid || !validIndices(path, purpose) {
+		return fmt.Errorf("%w: %s", ErrInvalidPath, path)
+	}
+	return nil
+}
+
+// validIndices reports whether the first index of path is purpose and every index is
+// below hardenedOffset. An index of 2^31 or more would otherwise be derived as another,
+// hardened, child.
+func validIndices(path string, purpose uint64) bool {
+	segments := strings.Split(strings.TrimPrefix(path, "m/"), "/")
+	for i, segment := range segments {
+		index, err := strconv.ParseUint(strings.TrimSuffix(segment, "'"), 10, 32)
+		if err != nil || index >= hardenedOffset || (i == 0 && index != purpose) {
+			return false
+		}
+	}
+	return true
+}
+
+// Derive returns the keypair of scheme at the account and address index.
+func (w *Wallet) Derive(scheme config.Scheme, account, address uint32) (*Account, error) {
+	path, err := Path(scheme, account, address)
+	if err != nil {
+		return nil, err
+	}
+	kp, err := w.DeriveForPath(scheme, path)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{AccountIndex: account, AddressIndex: address, Path: path, Keypair: kp}, nil
+}
+
+// DeriveForPath returns the keypair of scheme at a custom derivation path.
+func (w *Wallet) DeriveForPath(scheme config.Scheme, path string) (*keypair.Keypair, error) {
+	if err := ValidatePath(scheme, path); err != nil {
+		return nil, err
+	}
+	return keypair.NewKeypairWithSeed(w.seed, scheme, path)
+}
+
+// ActivityFunc reports whether address has been used.
+type ActivityFunc func(ctx context.Context, address string) (bool, error)
+
+// TransactionActivity returns an ActivityFunc that considers an address used once it
+// has sent or received a transaction, as seen by MgoXQueryTransactionBlocks.
+func TransactionActivity(cli *client.Client) ActivityFunc {
+	return func(ctx context.Context, address string) (bool, error) {
+		for _, f := range []filter.TransactionFilter{filter.FromAddress(address), filter.ToAddress(address)} {
+			rsp, err := cli.MgoXQueryTransactionBlocks(ctx, request.MgoXQueryTransactionBlocksRequest{
+				MgoTransactionBlockResponseQuery: request.MgoTransactionBlockResponseQuery{TransactionFilter: f},
+				Limit:                            1,
+			})
+			if err != nil {
+				return false, err
+			}
+			if len(rsp.Data) > 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// Discover returns the used accounts of scheme, checking the first address of each
+// account in order with hasActivity until gapLimit consecutive accounts are unused. A
+// gapLimit of zero or less is DefaultGapLimit.
+func (w *Wallet) Discover(ctx context.Context, scheme config.Scheme, gapLimit int, hasActivity ActivityFunc) ([]*Account, error) {
+	if gapLimit <= 0 {
+		gapLimit = DefaultGapLimit
+	}
+	var accounts []*Account
+	for index, gap := uint32(0), 0; gap < gapLimit; index++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		account, err := w.Derive(scheme, index, 0)
+		if err != nil {
+			return nil, err
+		}
+		used, err := hasActivity(ctx, account.Keypair.MgoAddress())
+		if err != nil {
+			return nil, fmt.Errorf("account %d: %w", index, err)
+		}
+		if used {
+			accounts = append(accounts, account)
+			gap = 0
+		} else {
+			gap++
+		}
+	}
+	return accounts, nil
+}
@@ -0,0 +1,91 @@
+package keypair
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/utils"
+)
+
+// KeySigner signs digests with a key whose private material does not have to be
+// available to the process, e.g. a key held by a KMS or an HSM. *Keypair implements it.
+type KeySigner interface {
+	// SignatureScheme returns the signature scheme flag of the key.
+	SignatureScheme() config.Scheme
+	// PublicKeyBytes returns the raw public key.
+	PublicKeyBytes() []byte
+	// SignDigest signs the Keccak-256 digest of an intent message and returns the raw signature.
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// SignatureScheme returns the signature scheme flag of the Keypair.
+func (k *Keypair) SignatureScheme() config.Scheme {
+	return k.Scheme
+}
+
+// SignDigest signs the digest with the Keypair's private key.
+func (k *Keypair) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	return k.Sign(digest), nil
+}
+
+// SignerMgoAddress returns the MGO address controlled by the signer's public key.
+func SignerMgoAddress(signer KeySigner) string {
+	inputBytes := append([]byte{byte(signer.SignatureScheme())}, signer.PublicKeyBytes()...)
+	return "0x" + hex.EncodeToString(utils.Keccak256(inputBytes))[:config.MGO_ADDRESS_LENGTH]
+}
+
+// SignTransactionBlock signs base64 encoded transaction data with the signer and returns
+// the serialized signature `flag || signature || public key`, base64 encoded.
+func SignTransactionBlock(ctx context.Context, signer KeySigner, txn *model.TxnMetaData) (*SignedTransactionSerializedSig, error) {
+	txBytes, err := base64.StdEncoding.DecodeString(txn.TxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signIntentMessage(ctx, signer, txBytes, config.TransactionData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedTransactionSerializedSig{
+		TxBytes:   txn.TxBytes,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// SignPersonalMessage signs a personal message with the signer and returns the serialized
+// signature `flag || signature || public key`.
+func SignPersonalMessage(ctx context.Context, signer KeySigner, message []byte) ([]byte, error) {
+	bcsEncodedMsg, err := bcs.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return signIntentMessage(ctx, signer, bcsEncodedMsg, config.PersonalMessage)
+}
+
+func signIntentMessage(ctx context.Context, signer KeySigner, message []byte, intent config.Signtype) ([]byte, error) {
+	if signer == nil {
+		return nil, errors.New("signer not set")
+	}
+	digest := digestData(dataWithIntent(message, intent))
+	signature, err := signer.SignDigest(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializeSignature(signer.SignatureScheme(), signature, signer.PublicKeyBytes()), nil
+}
+
+func serializeSignature(scheme config.Scheme, signature []byte, publicKey []byte) []byte {
+	serializedSignature := make([]byte, 1+len(signature)+len(publicKey))
+	serializedSignature[0] = byte(scheme)
+	copy(serializedSignature[1:], signature)
+	copy(serializedSignature[1+len(signature):], publicKey)
+	return serializedSignature
+}
@@ -6,9 +6,11 @@ import (
 	"encoding/hex"
 	"errors"
 
+	"github.com/mangonet-labs/mgo-go-sdk/account/multisig"
 	"github.com/mangonet-labs/mgo-go-sdk/account/signer"
 	"github.com/mangonet-labs/mgo-go-sdk/account/signer/ed25519"
 	"github.com/mangonet-labs/mgo-go-sdk/account/signer/secp256k1"
+	"github.com/mangonet-labs/mgo-go-sdk/account/signer/secp256r1"
 	"github.com/mangonet-labs/mgo-go-sdk/bcs"
 	"github.com/mangonet-labs/mgo-go-sdk/config"
 	"github.com/mangonet-labs/mgo-go-sdk/model"
@@ -43,6 +45,12 @@ func NewKeypair(scheme config.Scheme) (*Keypair, error) {
 			return nil, err
 		}
 		return &Keypair{Scheme: scheme, Signer: signer}, nil
+	case config.Secp256r1Flag:
+		signer, err := secp256r1.NewSigner()
+		if err != nil {
+			return nil, err
+		}
+		return &Keypair{Scheme: scheme, Signer: signer}, nil
 	default:
 		return nil, errors.New("invalid signature scheme flag")
 	}
@@ -67,6 +75,12 @@ func NewKeypairWithPrivateKey(scheme config.Scheme, privateKey string) (*Keypair
 			return nil, err
 		}
 		return &Keypair{Scheme: scheme, Signer: signer}, nil
+	case config.Secp256r1Flag:
+		signer, err := secp256r1.NewSignerByHex(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		return &Keypair{Scheme: scheme, Signer: signer}, nil
 	default:
 		return nil, errors.New("invalid signature scheme flag")
 	}
@@ -99,6 +113,13 @@ func NewKeypairWithMnemonic(mnemonic string, keytype config.Scheme) (*Keypair, e
 	if !ok {
 		return nil, errors.New("invalid signature scheme flag")
 	}
+	return NewKeypairWithSeed(seed, keytype, derivation)
+}
+
+// NewKeypairWithSeed returns a new Keypair derived from a BIP39 seed at the given
+// derivation path. Ed25519 paths must be fully hardened, e.g. `m/44'/938'/0'/0'/0'`,
+// while secp256k1 and secp256r1 paths are BIP32 paths such as `m/54'/938'/0'/0/0`.
+func NewKeypairWithSeed(seed []byte, keytype config.Scheme, derivation string) (*Keypair, error) {
 	var privateKey []byte
 	switch keytype {
 	case config.Secp256k1Flag:
@@ -113,6 +134,12 @@ func NewKeypairWithMnemonic(mnemonic string, keytype config.Scheme) (*Keypair, e
 			return nil, err
 		}
 		privateKey = key.Key
+	case config.Secp256r1Flag:
+		key, err := secp256r1.DeriveForPath(derivation, seed)
+		if err != nil {
+			return nil, err
+		}
+		privateKey = key.Key
 	default:
 		return nil, errors.New("invalid signature scheme flag")
 	}
@@ -125,8 +152,7 @@ func NewKeypairWithMnemonic(mnemonic string, keytype config.Scheme) (*Keypair, e
 // It is derived from the public key of the signer by taking the first 64 characters of the Keccak-256 hash
 // of the flag byte and the public key.
 func (k *Keypair) MgoAddress() string {
-	inputBytes := append([]byte{byte(k.Scheme)}, k.PublicKeyBytes()...)
-	return "0x" + hex.EncodeToString(utils.Keccak256(inputBytes))[:config.MGO_ADDRESS_LENGTH]
+	return SignerMgoAddress(k)
 }
 
 // MgoPrivateKey returns the MGO private key of the Keypair instance as a bech32-encoded string with the prefix "mgoprivkey".
@@ -168,7 +194,7 @@ func (k *Keypair) SignPersonalMessage(message []byte) []byte {
 	sigBytes := k.SignMessage(bcsEncodedMsg.Bytes(), config.PersonalMessage)
 	publicKey := k.PublicKeyBytes()
 	signData := append(sigBytes, publicKey...)
-	signData = append([]byte{byte(config.Ed25519Flag)}, signData...)
+	signData = append([]byte{byte(k.Scheme)}, signData...)
 	return signData
 }
 
@@ -214,15 +240,12 @@ func digestData(data []byte) []byte {
 // used to ensure the signature, scheme, and public key can be easily
 // transmitted and stored as a single string.
 func (k *Keypair) toSerializedSignature(signature []byte) string {
-	signatureLen := len(signature)
-	pubKeyLen := len(k.PublicKeyBytes())
-	serializedSignature := make([]byte, 1+signatureLen+pubKeyLen)
-	serializedSignature[0] = byte(k.Scheme)
-	copy(serializedSignature[1:], signature)
-	copy(serializedSignature[1+signatureLen:], k.PublicKeyBytes())
-	return base64.StdEncoding.EncodeToString(serializedSignature)
+	return base64.StdEncoding.EncodeToString(serializeSignature(k.Scheme, signature, k.PublicKeyBytes()))
 }
 
+// signatureLength is the length of the raw signatures of all single key schemes.
+const signatureLength = 64
+
 type SignatureInfo struct {
 	SerializedSignature []byte
 	SignatureScheme     string
@@ -234,14 +257,25 @@ type SignatureInfo struct {
 // ExtractSignerMgoAddress takes a serialized signature and returns the MGO address of the signer as a hexadecimal string prefixed with "0x".
 // The method extracts the signature scheme from the first byte of the signature, parses the signature into a SignatureInfo object,
 // and then derives the MGO address from the public key of the signer by taking the first 64 characters of the Keccak-256 hash
-// of the flag byte and the public key. If the signature is invalid, the method returns an error.
+// of the flag byte and the public key. For multisig signatures it returns the address of the multisig public key.
+// If the signature is invalid, the method returns an error.
 func ExtractSignerMgoAddress(sig []byte) (string, error) {
-	schema := config.Scheme(sig[0])
 	signatureScheme := GetSignatureScheme(sig)
-	if sig == nil || signatureScheme == "" {
+	if signatureScheme == "" {
 		return "", errors.New("invalid signature")
 	}
+	schema := config.Scheme(sig[0])
+	if schema == config.MultiSigFlag {
+		multiSig, err := multisig.ParseMultiSig(sig)
+		if err != nil {
+			return "", err
+		}
+		return multiSig.MgoAddress(), nil
+	}
 	signatureInfo := ParseSignatureInfo(sig, signatureScheme)
+	if signatureInfo == nil {
+		return "", errors.New("invalid signature")
+	}
 	inputBytes := append([]byte{byte(schema)}, signatureInfo.PublicKey...)
 	return "0x" + hex.EncodeToString(utils.Keccak256(inputBytes))[:config.MGO_ADDRESS_LENGTH], nil
 }
@@ -251,10 +285,16 @@ func ExtractSignerMgoAddress(sig []byte) (string, error) {
 // is valid, false otherwise.
 func VerifyPersonalMessage(msg []byte, sig []byte) bool {
 	signatureScheme := GetSignatureScheme(sig)
-	if sig == nil || signatureScheme == "" {
+	if signatureScheme == "" {
 		return false
 	}
+	if config.SIGNATURE_SCHEME_TO_FLAG[signatureScheme] == config.MultiSigFlag {
+		return multisig.VerifyPersonalMessage(msg, sig) == nil
+	}
 	signatureInfo := ParseSignatureInfo(sig, signatureScheme)
+	if signatureInfo == nil {
+		return false
+	}
 	publickey := signatureInfo.PublicKey
 
 	bcsEncodedMsg := bytes.Buffer{}
@@ -269,6 +309,8 @@ func VerifyPersonalMessage(msg []byte, sig []byte) bool {
 		return ed25519.Verify(publickey, digest, signatureInfo.Signature)
 	case config.Secp256k1Flag:
 		return secp256k1.Verify(publickey, digest, signatureInfo.Signature)
+	case config.Secp256r1Flag:
+		return secp256r1.Verify(publickey, digest, signatureInfo.Signature)
 	default:
 		return false
 	}
@@ -280,10 +322,16 @@ func VerifyPersonalMessage(msg []byte, sig []byte) bool {
 // is valid, false otherwise.
 func VerifyTransactionBlock(txn []byte, sig []byte) bool {
 	signatureScheme := GetSignatureScheme(sig)
-	if sig == nil || signatureScheme == "" {
+	if signatureScheme == "" {
 		return false
 	}
+	if config.SIGNATURE_SCHEME_TO_FLAG[signatureScheme] == config.MultiSigFlag {
+		return multisig.VerifyTransactionBlock(txn, sig) == nil
+	}
 	signatureInfo := ParseSignatureInfo(sig, signatureScheme)
+	if signatureInfo == nil {
+		return false
+	}
 	publickey := signatureInfo.PublicKey
 
 	intentMessage := dataWithIntent(txn, config.TransactionData)
@@ -294,6 +342,8 @@ func VerifyTransactionBlock(txn []byte, sig []byte) bool {
 		return ed25519.Verify(publickey, digest, signatureInfo.Signature)
 	case config.Secp256k1Flag:
 		return secp256k1.Verify(publickey, digest, signatureInfo.Signature)
+	case config.Secp256r1Flag:
+		return secp256r1.Verify(publickey, digest, signatureInfo.Signature)
 	default:
 		return false
 	}
@@ -301,8 +351,12 @@ func VerifyTransactionBlock(txn []byte, sig []byte) bool {
 
 // GetSignatureScheme returns the signature scheme corresponding to the given byte slice.
 // The scheme is determined from the first byte of the given byte slice, and is used to
-// determine the size of the signature and public key in the given byte slice.
+// determine the size of the signature and public key in the given byte slice. It returns
+// an empty string if the byte slice is empty or the flag is unknown.
 func GetSignatureScheme(bytes []byte) string {
+	if len(bytes) == 0 {
+		return ""
+	}
 	return config.SIGNATURE_FLAG_TO_SCHEME[config.Scheme(bytes[0])]
 }
 
@@ -310,8 +364,13 @@ func GetSignatureScheme(bytes []byte) string {
 // as input, and returns a SignatureInfo object containing the signature, public key, and scheme.
 // The method slices the input byte slice to extract the signature and public key, and returns a SignatureInfo object
 // containing the serialized signature, the signature, the public key, and the scheme.
+// It returns nil if the scheme is not a single key scheme or the byte slice is not
+// `flag || 64 bytes signature || public key` for that scheme.
 func ParseSignatureInfo(bytes []byte, signatureScheme string) *SignatureInfo {
-	size := config.SIGNATURE_SCHEME_TO_SIZE[signatureScheme]
+	size, ok := config.SIGNATURE_SCHEME_TO_SIZE[signatureScheme]
+	if !ok || len(bytes) != 1+signatureLength+size || GetSignatureScheme(bytes) != signatureScheme {
+		return nil
+	}
 	signature := bytes[1 : len(bytes)-size]
 	publicKey := bytes[1+len(signature):]
 
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/mangonet-labs/mgo-go-sdk/utils"
@@ -30,8 +31,7 @@ func DecodeMgoPrivateKey(key string) (scheme config.Scheme, privateKey string, e
 		return
 	}
 	secretKey := extendedSecretKey[1:]
-	_, exists := config.SIGNATURE_FLAG_TO_SCHEME[config.Scheme(extendedSecretKey[0])]
-	if !exists {
+	if !isKeyScheme(config.Scheme(extendedSecretKey[0])) {
 		err = errors.New("invalid signature scheme flag")
 		return
 	}
@@ -61,14 +61,19 @@ func EncodeMgoPrivateKey(scheme config.Scheme, privatekey string) (string, error
 	return bech32.Encode(config.MGO_PRIVATE_KEY_PREFIX, words)
 }
 
+// DecodeBase64WithFlag decodes a base64 encoded `flag || private key`, the format of the
+// entries of the CLI keystore, and returns the scheme and the hex encoded private key.
 func DecodeBase64WithFlag(key string) (scheme config.Scheme, privateKey string, err error) {
 	extendedSecretKey, err := base64.StdEncoding.DecodeString(key)
 	if err != nil {
 		return
 	}
+	if len(extendedSecretKey) != config.PRIVATE_KEY_SIZE+1 {
+		err = errors.New("invalid bytes length")
+		return
+	}
 	secretKey := extendedSecretKey[1:]
-	_, exists := config.SIGNATURE_FLAG_TO_SCHEME[config.Scheme(extendedSecretKey[0])]
-	if !exists {
+	if !isKeyScheme(config.Scheme(extendedSecretKey[0])) {
 		err = errors.New("invalid signature scheme flag")
 		return
 	}
@@ -76,22 +81,42 @@ func DecodeBase64WithFlag(key string) (scheme config.Scheme, privateKey string,
 	return config.Scheme(extendedSecretKey[0]), hex.EncodeToString(secretKey), nil
 }
 
+// EncodeBase64WithFlag encodes a hex encoded private key as base64 `flag || private key`,
+// the format of the entries of the CLI keystore.
 func EncodeBase64WithFlag(scheme config.Scheme, privateKey string) (string, error) {
-	if len(privateKey) != config.PRIVATE_KEY_SIZE {
+	if strings.HasPrefix(privateKey, "0x") || strings.HasPrefix(privateKey, "0X") {
+		privateKey = privateKey[2:]
+	}
+	key, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return "", err
+	}
+	if len(key) != config.PRIVATE_KEY_SIZE {
 		return "", errors.New("invalid bytes length")
 	}
-	privKeyBytes := append([]byte{byte(scheme)}, []byte(privateKey)...)
+	privKeyBytes := append([]byte{byte(scheme)}, key...)
 	return base64.StdEncoding.EncodeToString(privKeyBytes), nil
 }
 
+// isKeyScheme reports whether scheme is the scheme of a single key. The multisig flag
+// only tags signatures and public keys, never a private key.
+func isKeyScheme(scheme config.Scheme) bool {
+	_, ok := config.SIGNATURE_SCHEME_TO_SIZE[config.SIGNATURE_FLAG_TO_SCHEME[scheme]]
+	return ok
+}
+
 // PublicKeyToMgoAddress takes a public key and a signature scheme, and returns the corresponding MGO address
 // as a hexadecimal string prefixed with "0x". It is derived from the public key of the signer by taking the
-// first 64 characters of the Keccak-256 hash of the flag byte and the public key. If the scheme is not Ed25519,
-// it returns an error.
+// first 64 characters of the Keccak-256 hash of the flag byte and the public key. If the scheme is not a single
+// key scheme or the public key does not have the size of the scheme, it returns an error.
 func PublicKeyToMgoAddress(publicKey []byte, schema config.Scheme) (string, error) {
-	if schema != config.Ed25519Flag {
+	size, ok := config.SIGNATURE_SCHEME_TO_SIZE[config.SIGNATURE_FLAG_TO_SCHEME[schema]]
+	if !ok {
 		return "", errors.New("invalid signature scheme flag")
 	}
-	inputBytes := append([]byte{byte(config.Ed25519Flag)}, publicKey...)
+	if len(publicKey) != size {
+		return "", fmt.Errorf("invalid public key length: expected %d bytes, got %d", size, len(publicKey))
+	}
+	inputBytes := append([]byte{byte(schema)}, publicKey...)
 	return "0x" + hex.EncodeToString(utils.Keccak256(inputBytes))[:config.MGO_ADDRESS_LENGTH], nil
 }
@@ -0,0 +1,100 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	encryptedVersion = 1
+	scryptN          = 1 << 15
+	scryptR          = 8
+	scryptP          = 1
+	keyLength        = 32
+	saltLength       = 16
+)
+
+type encryptedFile struct {
+	Version    int          `json:"version"`
+	Kdf        string       `json:"kdf"`
+	KdfParams  scryptParams `json:"kdfparams"`
+	Cipher     string       `json:"cipher"`
+	Nonce      []byte       `json:"nonce"`
+	Ciphertext []byte       `json:"ciphertext"`
+}
+
+type scryptParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt []byte `json:"salt"`
+}
+
+// isEncrypted reports whether data is an encrypted keystore rather than a JSON array.
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+func encrypt(plaintext []byte, passphrase []byte) ([]byte, error) {
+	params := scryptParams{N: scryptN, R: scryptR, P: scryptP, Salt: make([]byte, saltLength)}
+	if _, err := rand.Read(params.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(encryptedFile{
+		Version:    encryptedVersion,
+		Kdf:        "scrypt",
+		KdfParams:  params,
+		Cipher:     "aes-256-gcm",
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, nil),
+	}, "", "  ")
+}
+
+func decrypt(data []byte, passphrase []byte) ([]byte, error) {
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyFile, err)
+	}
+	if file.Version != encryptedVersion || file.Kdf != "scrypt" || file.Cipher != "aes-256-gcm" {
+		return nil, fmt.Errorf("%w: unsupported encryption %d %s %s", ErrInvalidKeyFile, file.Version, file.Kdf, file.Cipher)
+	}
+	aead, err := newAEAD(passphrase, file.KdfParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid nonce", ErrInvalidKeyFile)
+	}
+	plaintext, err := aead.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+	return plaintext, nil
+}
+
+func newAEAD(passphrase []byte, params scryptParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, params.Salt, params.N, params.R, params.P, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyFile, err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,317 @@
+// Package keystore loads and saves keys in the format of the CLI keystore.
+//
+// A keystore file, such as `~/.mgo/mgo_config/mgo.keystore`, is a JSON array of base64
+// encoded `flag || private key` entries. The aliases of the keys are kept next to it in
+// a `.aliases` file, e.g. `mgo.aliases`, as a JSON array of
+// {"alias": "...", "public_key_base64": "<flag || public key>"} entries.
+//
+// A keystore may be encrypted at rest with a passphrase. The keystore file then holds
+// the JSON array encrypted with AES-256-GCM under a key derived with scrypt; the aliases
+// file, which only holds public keys, stays readable.
+package keystore
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/utils"
+)
+
+var (
+	ErrKeyNotFound    = errors.New("key not found")
+	ErrAliasExists    = errors.New("alias already exists")
+	ErrKeyExists      = errors.New("key already exists")
+	ErrEncrypted      = errors.New("keystore is encrypted")
+	ErrWrongPassword  = errors.New("wrong passphrase or corrupted keystore")
+	ErrInvalidKeyFile = errors.New("invalid keystore file")
+)
+
+// Entry describes a key of the keystore.
+type Entry struct {
+	Alias      string
+	MgoAddress string
+	Keypair    *keypair.Keypair
+}
+
+type alias struct {
+	Alias           string `json:"alias"`
+	PublicKeyBase64 string `json:"public_key_base64"`
+}
+
+// Keystore holds keys in memory; Save writes them back to its files.
+type Keystore struct {
+	path        string
+	aliasesPath string
+	passphrase  []byte
+
+	entries []*Entry
+}
+
+// Load loads the keystore at path, or returns an empty keystore if the file does not
+// exist. It fails with ErrEncrypted if the keystore is encrypted.
+func Load(path string) (*Keystore, error) {
+	return load(path, nil)
+}
+
+// LoadEncrypted loads the keystore at path encrypted with passphrase, or returns an
+// empty keystore if the file does not exist. A plain keystore is loaded as is and
+// encrypted on the next Save.
+func LoadEncrypted(path string, passphrase string) (*Keystore, error) {
+	return load(path, []byte(passphrase))
+}
+
+func load(path string, passphrase []byte) (*Keystore, error) {
+	ks := &Keystore{
+		path:        path,
+		aliasesPath: strings.TrimSuffix(path, filepath.Ext(path)) + ".aliases",
+		passphrase:  passphrase,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if isEncrypted(data) {
+		if passphrase == nil {
+			return nil, ErrEncrypted
+		}
+		if data, err = decrypt(data, passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyFile, err)
+	}
+	for _, key := range keys {
+		scheme, privateKey, err := keypair.DecodeBase64WithFlag(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKeyFile, err)
+		}
+		kp, err := keypair.NewKeypairWithPrivateKey(scheme, privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKeyFile, err)
+		}
+		ks.entries = append(ks.entries, &Entry{MgoAddress: kp.MgoAddress(), Keypair: kp})
+	}
+
+	aliases, err := ks.readAliases()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range ks.entries {
+		entry.Alias = aliases[publicKeyBase64(entry.Keypair)]
+	}
+	for _, entry := range ks.entries {
+		if entry.Alias == "" {
+			entry.Alias = ks.newAlias()
+		}
+	}
+	return ks, nil
+}
+
+func (ks *Keystore) readAliases() (map[string]string, error) {
+	aliases := map[string]string{}
+	data, err := os.ReadFile(ks.aliasesPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return aliases, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []alias
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyFile, err)
+	}
+	for _, entry := range entries {
+		aliases[entry.PublicKeyBase64] = entry.Alias
+	}
+	return aliases, nil
+}
+
+// Save writes the keys and their aliases to the files of the keystore, encrypting the
+// keys if the keystore was loaded with a passphrase.
+func (ks *Keystore) Save() error {
+	keys := make([]string, 0, len(ks.entries))
+	aliases := make([]alias, 0, len(ks.entries))
+	for _, entry := range ks.entries {
+		key, err := keypair.EncodeBase64WithFlag(entry.Keypair.Scheme, entry.Keypair.PrivateKeyHex())
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		aliases = append(aliases, alias{Alias: entry.Alias, PublicKeyBase64: publicKeyBase64(entry.Keypair)})
+	}
+
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	if ks.passphrase != nil {
+		if data, err = encrypt(data, ks.passphrase); err != nil {
+			return err
+		}
+	}
+	aliasData, err := json.MarshalIndent(aliases, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(ks.path, data); err != nil {
+		return err
+	}
+	return writeFile(ks.aliasesPath, aliasData)
+}
+
+// Keys returns the keys in the order they were added.
+func (ks *Keystore) Keys() []Entry {
+	entries := make([]Entry, len(ks.entries))
+	for i, entry := range ks.entries {
+		entries[i] = *entry
+	}
+	return entries
+}
+
+// Get returns the key with the alias or address.
+func (ks *Keystore) Get(aliasOrAddress string) (*keypair.Keypair, error) {
+	entry, err := ks.find(aliasOrAddress)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Keypair, nil
+}
+
+// Add adds a key under alias. An empty alias is replaced with a generated one.
+func (ks *Keystore) Add(kp *keypair.Keypair, alias string) error {
+	address := kp.MgoAddress()
+	for _, entry := range ks.entries {
+		if entry.MgoAddress == address {
+			return fmt.Errorf("%w: %s", ErrKeyExists, address)
+		}
+		if alias != "" && entry.Alias == alias {
+			return fmt.Errorf("%w: %s", ErrAliasExists, alias)
+		}
+	}
+	if alias == "" {
+		alias = ks.newAlias()
+	}
+	ks.entries = append(ks.entries, &Entry{Alias: alias, MgoAddress: address, Keypair: kp})
+	return nil
+}
+
+// Import adds a key given as a bech32 `mgoprivkey` string under alias.
+func (ks *Keystore) Import(mgoPrivateKey string, alias string) (*keypair.Keypair, error) {
+	kp, err := keypair.NewKeypairWithMgoPrivateKey(mgoPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := ks.Add(kp, alias); err != nil {
+		return nil, err
+	}
+	return kp, nil
+}
+
+// Export returns the key with the alias or address as a bech32 `mgoprivkey` string.
+func (ks *Keystore) Export(aliasOrAddress string) (string, error) {
+	entry, err := ks.find(aliasOrAddress)
+	if err != nil {
+		return "", err
+	}
+	return keypair.EncodeMgoPrivateKey(entry.Keypair.Scheme, entry.Keypair.PrivateKeyHex())
+}
+
+// Remove removes the key with the alias or address.
+func (ks *Keystore) Remove(aliasOrAddress string) error {
+	entry, err := ks.find(aliasOrAddress)
+	if err != nil {
+		return err
+	}
+	for i, e := range ks.entries {
+		if e == entry {
+			ks.entries = append(ks.entries[:i], ks.entries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// SetAlias renames the key with the alias or address.
+func (ks *Keystore) SetAlias(aliasOrAddress string, alias string) error {
+	entry, err := ks.find(aliasOrAddress)
+	if err != nil {
+		return err
+	}
+	for _, e := range ks.entries {
+		if e != entry && e.Alias == alias {
+			return fmt.Errorf("%w: %s", ErrAliasExists, alias)
+		}
+	}
+	entry.Alias = alias
+	return nil
+}
+
+func (ks *Keystore) find(aliasOrAddress string) (*Entry, error) {
+	for _, entry := range ks.entries {
+		if entry.Alias == aliasOrAddress {
+			return entry, nil
+		}
+	}
+	if utils.IsValidMgoAddress(model.MgoAddress(aliasOrAddress)) {
+		address := string(utils.NormalizeMgoAddress(aliasOrAddress))
+		for _, entry := range ks.entries {
+			if entry.MgoAddress == address {
+				return entry, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, aliasOrAddress)
+}
+
+func (ks *Keystore) newAlias() string {
+	for i := len(ks.entries); ; i++ {
+		alias := fmt.Sprintf("key-%d", i)
+		if _, err := ks.find(alias); err != nil {
+			return alias
+		}
+	}
+}
+
+func publicKeyBase64(kp *keypair.Keypair) string {
+	return base64.StdEncoding.EncodeToString(append([]byte{byte(kp.Scheme)}, kp.PublicKeyBytes()...))
+}
+
+// writeFile replaces the file at path atomically, readable by its owner only.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,412 @@
+package multisig
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/signer/ed25519"
+	"github.com/mangonet-labs/mgo-go-sdk/account/signer/secp256k1"
+	"github.com/mangonet-labs/mgo-go-sdk/account/signer/secp256r1"
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/utils"
+)
+
+const (
+	// MaxSigners is the maximum number of public keys in a multisig public key.
+	MaxSigners = 10
+	// signatureLength is the length of a compressed ed25519, secp256k1 or secp256r1 signature.
+	signatureLength = 64
+)
+
+var (
+	ErrInvalidThreshold    = errors.New("invalid multisig threshold")
+	ErrInvalidPublicKeys   = errors.New("invalid multisig public keys")
+	ErrInvalidSignature    = errors.New("invalid multisig signature")
+	ErrUnknownSigner       = errors.New("signature does not belong to any multisig public key")
+	ErrDuplicateSigner     = errors.New("duplicate signature for the same public key")
+	ErrInsufficientWeight  = errors.New("signatures do not reach the multisig threshold")
+	ErrUnsupportedScheme   = errors.New("unsupported signature scheme")
+	ErrSignatureNotMatched = errors.New("signature verification failed")
+)
+
+// WeightedPublicKey is a member of a multisig public key.
+type WeightedPublicKey struct {
+	Scheme    config.Scheme
+	PublicKey []byte
+	Weight    uint8
+}
+
+// MultiSigPublicKey is a set of weighted public keys and the total weight of
+// signatures required to sign on behalf of its address.
+type MultiSigPublicKey struct {
+	PublicKeys []WeightedPublicKey
+	Threshold  uint16
+}
+
+// MultiSig is a multisig signature: the signatures of a subset of the public keys,
+// ordered by public key index, and a bitmap of the public keys that signed.
+type MultiSig struct {
+	Signatures []CompressedSignature
+	Bitmap     uint16
+	PublicKey  *MultiSigPublicKey
+}
+
+// CompressedSignature is a single signature without its public key.
+type CompressedSignature struct {
+	Scheme    config.Scheme
+	Signature []byte
+}
+
+// NewMultiSigPublicKey creates a multisig public key. There must be between 1 and
+// MaxSigners distinct public keys with a positive weight, and the threshold must be
+// reachable by their total weight.
+func NewMultiSigPublicKey(threshold uint16, publicKeys ...WeightedPublicKey) (*MultiSigPublicKey, error) {
+	if len(publicKeys) == 0 || len(publicKeys) > MaxSigners {
+		return nil, fmt.Errorf("%w: expected 1 to %d public keys, got %d", ErrInvalidPublicKeys, MaxSigners, len(publicKeys))
+	}
+
+	var totalWeight uint16
+	seen := map[string]bool{}
+	for _, publicKey := range publicKeys {
+		size, err := publicKeySize(publicKey.Scheme)
+		if err != nil {
+			return nil, err
+		}
+		if len(publicKey.PublicKey) != size {
+			return nil, fmt.Errorf("%w: expected %d bytes public key, got %d", ErrInvalidPublicKeys, size, len(publicKey.PublicKey))
+		}
+		if publicKey.Weight == 0 {
+			return nil, fmt.Errorf("%w: weight must be positive", ErrInvalidPublicKeys)
+		}
+		key := string(append([]byte{byte(publicKey.Scheme)}, publicKey.PublicKey...))
+		if seen[key] {
+			return nil, fmt.Errorf("%w: duplicate public key %x", ErrInvalidPublicKeys, publicKey.PublicKey)
+		}
+		seen[key] = true
+		totalWeight += uint16(publicKey.Weight)
+	}
+	if threshold == 0 || threshold > totalWeight {
+		return nil, fmt.Errorf("%w: %d with a total weight of %d", ErrInvalidThreshold, threshold, totalWeight)
+	}
+
+	return &MultiSigPublicKey{
+		PublicKeys: publicKeys,
+		Threshold:  threshold,
+	}, nil
+}
+
+// MgoAddress returns the address of the multisig public key: the Keccak-256 hash of the
+// multisig flag, the little endian threshold and, for every public key, its scheme flag,
+// bytes and weight.
+func (m *MultiSigPublicKey) MgoAddress() string {
+	data := []byte{byte(config.MultiSigFlag)}
+	data = binary.LittleEndian.AppendUint16(data, m.Threshold)
+	for _, publicKey := range m.PublicKeys {
+		data = append(data, byte(publicKey.Scheme))
+		data = append(data, publicKey.PublicKey...)
+		data = append(data, publicKey.Weight)
+	}
+
+	return "0x" + hex.EncodeToString(utils.Keccak256(data))[:config.MGO_ADDRESS_LENGTH]
+}
+
+// CombineSignatures combines serialized signatures (`flag || signature || public key`, base64
+// encoded, as returned by `Keypair.SignTransactionBlock`) into a base64 encoded multisig
+// signature. The signatures may be given in any order, but their total weight must reach
+// the threshold.
+func (m *MultiSigPublicKey) CombineSignatures(signatures []string) (string, error) {
+	type indexedSignature struct {
+		index     int
+		signature CompressedSignature
+	}
+
+	var (
+		indexed []indexedSignature
+		bitmap  uint16
+		weight  uint16
+	)
+	for _, serialized := range signatures {
+		b, err := base64.StdEncoding.DecodeString(serialized)
+		if err != nil {
+			return "", err
+		}
+		scheme, signature, publicKey, err := parseSerializedSignature(b)
+		if err != nil {
+			return "", err
+		}
+		index := m.publicKeyIndex(scheme, publicKey)
+		if index < 0 {
+			return "", fmt.Errorf("%w: %x", ErrUnknownSigner, publicKey)
+		}
+		if bitmap&(1<<index) != 0 {
+			return "", fmt.Errorf("%w: %x", ErrDuplicateSigner, publicKey)
+		}
+		bitmap |= 1 << index
+		weight += uint16(m.PublicKeys[index].Weight)
+		indexed = append(indexed, indexedSignature{
+			index:     index,
+			signature: CompressedSignature{Scheme: scheme, Signature: signature},
+		})
+	}
+	if weight < m.Threshold {
+		return "", fmt.Errorf("%w: weight %d of %d", ErrInsufficientWeight, weight, m.Threshold)
+	}
+
+	sort.Slice(indexed, func(i, j int) bool {
+		return indexed[i].index < indexed[j].index
+	})
+	multiSig := &MultiSig{
+		Bitmap:    bitmap,
+		PublicKey: m,
+	}
+	for _, s := range indexed {
+		multiSig.Signatures = append(multiSig.Signatures, s.signature)
+	}
+
+	return base64.StdEncoding.EncodeToString(multiSig.Serialize()), nil
+}
+
+func (m *MultiSigPublicKey) publicKeyIndex(scheme config.Scheme, publicKey []byte) int {
+	for i, weighted := range m.PublicKeys {
+		if weighted.Scheme == scheme && bytes.Equal(weighted.PublicKey, publicKey) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Marshal encodes the multisig public key as BCS.
+func (m *MultiSigPublicKey) Marshal() []byte {
+	data := bcs.ULEB128Encode(len(m.PublicKeys))
+	for _, publicKey := range m.PublicKeys {
+		data = append(data, bcs.ULEB128Encode(schemeVariant(publicKey.Scheme))...)
+		data = append(data, publicKey.PublicKey...)
+		data = append(data, publicKey.Weight)
+	}
+
+	return binary.LittleEndian.AppendUint16(data, m.Threshold)
+}
+
+// Serialize encodes the multisig signature as `flag || bcs(multisig)`.
+func (s *MultiSig) Serialize() []byte {
+	data := []byte{byte(config.MultiSigFlag)}
+	data = append(data, bcs.ULEB128Encode(len(s.Signatures))...)
+	for _, signature := range s.Signatures {
+		data = append(data, bcs.ULEB128Encode(schemeVariant(signature.Scheme))...)
+		data = append(data, signature.Signature...)
+	}
+	data = binary.LittleEndian.AppendUint16(data, s.Bitmap)
+
+	return append(data, s.PublicKey.Marshal()...)
+}
+
+// ParseMultiSig decodes a serialized multisig signature.
+func ParseMultiSig(serialized []byte) (*MultiSig, error) {
+	if len(serialized) == 0 || config.Scheme(serialized[0]) != config.MultiSigFlag {
+		return nil, fmt.Errorf("%w: missing multisig flag", ErrInvalidSignature)
+	}
+	r := bytes.NewReader(serialized[1:])
+
+	count, _, err := bcs.ULEB128Decode[int](r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if count > MaxSigners {
+		return nil, fmt.Errorf("%w: too many signatures", ErrInvalidSignature)
+	}
+	multiSig := &MultiSig{PublicKey: &MultiSigPublicKey{}}
+	for i := 0; i < count; i++ {
+		scheme, err := readSchemeVariant(r)
+		if err != nil {
+			return nil, err
+		}
+		signature := make([]byte, signatureLength)
+		if _, err := io.ReadFull(r, signature); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+		}
+		multiSig.Signatures = append(multiSig.Signatures, CompressedSignature{Scheme: scheme, Signature: signature})
+	}
+	if err := binary.Read(r, binary.LittleEndian, &multiSig.Bitmap); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	count, _, err = bcs.ULEB128Decode[int](r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if count > MaxSigners {
+		return nil, fmt.Errorf("%w: too many public keys", ErrInvalidSignature)
+	}
+	for i := 0; i < count; i++ {
+		scheme, err := readSchemeVariant(r)
+		if err != nil {
+			return nil, err
+		}
+		size, err := publicKeySize(scheme)
+		if err != nil {
+			return nil, err
+		}
+		publicKey := make([]byte, size+1)
+		if _, err := io.ReadFull(r, publicKey); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+		}
+		multiSig.PublicKey.PublicKeys = append(multiSig.PublicKey.PublicKeys, WeightedPublicKey{
+			Scheme:    scheme,
+			PublicKey: publicKey[:size],
+			Weight:    publicKey[size],
+		})
+	}
+	if err := binary.Read(r, binary.LittleEndian, &multiSig.PublicKey.Threshold); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidSignature, r.Len())
+	}
+
+	return multiSig, nil
+}
+
+// MgoAddress returns the address of the multisig public key the signature was made for.
+func (s *MultiSig) MgoAddress() string {
+	return s.PublicKey.MgoAddress()
+}
+
+// Verify checks the signatures against the intent message digest and that the
+// weight of the public keys that signed reaches the threshold.
+func (s *MultiSig) Verify(digest []byte) error {
+	publicKey, err := NewMultiSigPublicKey(s.PublicKey.Threshold, s.PublicKey.PublicKeys...)
+	if err != nil {
+		return err
+	}
+
+	var weight uint16
+	next := 0
+	for index := range publicKey.PublicKeys {
+		if s.Bitmap&(1<<index) == 0 {
+			continue
+		}
+		if next >= len(s.Signatures) {
+			return fmt.Errorf("%w: bitmap does not match the signatures", ErrInvalidSignature)
+		}
+		signature := s.Signatures[next]
+		next++
+
+		weighted := publicKey.PublicKeys[index]
+		if signature.Scheme != weighted.Scheme {
+			return fmt.Errorf("%w: signature %d scheme does not match its public key", ErrInvalidSignature, next-1)
+		}
+		if !verify(weighted.Scheme, weighted.PublicKey, digest, signature.Signature) {
+			return fmt.Errorf("%w: public key %d", ErrSignatureNotMatched, index)
+		}
+		weight += uint16(weighted.Weight)
+	}
+	if next != len(s.Signatures) || s.Bitmap>>len(publicKey.PublicKeys) != 0 {
+		return fmt.Errorf("%w: bitmap does not match the signatures", ErrInvalidSignature)
+	}
+	if weight < publicKey.Threshold {
+		return fmt.Errorf("%w: weight %d of %d", ErrInsufficientWeight, weight, publicKey.Threshold)
+	}
+
+	return nil
+}
+
+// VerifyTransactionBlock verifies a serialized multisig signature over BCS transaction data.
+func VerifyTransactionBlock(txBytes []byte, signature []byte) error {
+	multiSig, err := ParseMultiSig(signature)
+	if err != nil {
+		return err
+	}
+
+	return multiSig.Verify(intentDigest(txBytes, config.TransactionData))
+}
+
+// VerifyPersonalMessage verifies a serialized multisig signature over a personal message.
+func VerifyPersonalMessage(message []byte, signature []byte) error {
+	multiSig, err := ParseMultiSig(signature)
+	if err != nil {
+		return err
+	}
+	bcsEncodedMsg, err := bcs.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return multiSig.Verify(intentDigest(bcsEncodedMsg, config.PersonalMessage))
+}
+
+func intentDigest(data []byte, intent config.Signtype) []byte {
+	return utils.Keccak256(append([]byte{byte(intent), 0, 0}, data...))
+}
+
+func verify(scheme config.Scheme, publicKey, digest, signature []byte) bool {
+	if len(signature) != signatureLength {
+		return false
+	}
+	switch scheme {
+	case config.Ed25519Flag:
+		return ed25519.Verify(publicKey, digest, signature)
+	case config.Secp256k1Flag:
+		return secp256k1.Verify(publicKey, digest, signature)
+	case config.Secp256r1Flag:
+		return secp256r1.Verify(publicKey, digest, signature)
+	default:
+		return false
+	}
+}
+
+// parseSerializedSignature splits `flag || signature || public key`.
+func parseSerializedSignature(b []byte) (config.Scheme, []byte, []byte, error) {
+	if len(b) == 0 {
+		return 0, nil, nil, fmt.Errorf("%w: empty signature", ErrInvalidSignature)
+	}
+	scheme := config.Scheme(b[0])
+	size, err := publicKeySize(scheme)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if len(b) != 1+signatureLength+size {
+		return 0, nil, nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidSignature, 1+signatureLength+size, len(b))
+	}
+
+	return scheme, b[1 : 1+signatureLength], b[1+signatureLength:], nil
+}
+
+func publicKeySize(scheme config.Scheme) (int, error) {
+	name, ok := config.SIGNATURE_FLAG_TO_SCHEME[scheme]
+	if !ok {
+		return 0, fmt.Errorf("%w: flag %d", ErrUnsupportedScheme, scheme)
+	}
+	size, ok := config.SIGNATURE_SCHEME_TO_SIZE[name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedScheme, name)
+	}
+
+	return size, nil
+}
+
+// schemeVariant is the index of the scheme in the PublicKey and CompressedSignature enums,
+// which follow the order of the signature scheme flags.
+func schemeVariant(scheme config.Scheme) int {
+	return int(scheme)
+}
+
+func readSchemeVariant(r io.Reader) (config.Scheme, error) {
+	variant, _, err := bcs.ULEB128Decode[int](r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	scheme := config.Scheme(variant)
+	if _, err := publicKeySize(scheme); err != nil {
+		return 0, err
+	}
+
+	return scheme, nil
+}
@@ -106,6 +106,12 @@ func (k *Key) RawSeed() [32]byte {
 	return rawSeed
 }
 
+// IsValidPath reports whether path is a fully hardened ed25519 path such as
+// `m/44'/938'/0'/0'/0'`.
+func IsValidPath(path string) bool {
+	return isValidHardenedPath(path)
+}
+
 func isValidHardenedPath(path string) bool {
 	re := regexp.MustCompile(`^m/44'/938'/\d+'/(\d+'/)*\d+'$`)
 	return re.MatchString(path)
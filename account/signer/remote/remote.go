@@ -0,0 +1,199 @@
+// Package remote implements a keypair.KeySigner that forwards signing requests to a
+// signing service over HTTP, and the handler that serves such a service.
+//
+// The protocol has two JSON endpoints relative to the service URL:
+//
+//	GET  /public-key  -> {"scheme": 0, "publicKey": "<base64>"}
+//	POST /sign        {"digest": "<base64>"} -> {"signature": "<base64>"}
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+)
+
+const (
+	publicKeyPath = "/public-key"
+	signPath      = "/sign"
+	digestLength  = 32
+)
+
+var ErrInvalidResponse = errors.New("invalid response from remote signer")
+
+type PublicKeyResponse struct {
+	Scheme    config.Scheme `json:"scheme"`
+	PublicKey string        `json:"publicKey"`
+}
+
+type SignRequest struct {
+	Digest string `json:"digest"`
+}
+
+type SignResponse struct {
+	Signature string `json:"signature"`
+}
+
+// Signer signs digests by calling a remote signing service.
+type Signer struct {
+	endpoint   string
+	httpClient *http.Client
+	header     http.Header
+	scheme     config.Scheme
+	publicKey  []byte
+}
+
+var _ keypair.KeySigner = (*Signer)(nil)
+
+// NewSigner creates a signer for the service at endpoint and fetches its public key.
+// Headers, e.g. authorization, are sent with every request. A nil httpClient uses
+// http.DefaultClient.
+func NewSigner(ctx context.Context, endpoint string, httpClient *http.Client, header http.Header) (*Signer, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	s := &Signer{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		httpClient: httpClient,
+		header:     header,
+	}
+
+	var rsp PublicKeyResponse
+	if err := s.do(ctx, http.MethodGet, publicKeyPath, nil, &rsp); err != nil {
+		return nil, err
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(rsp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	size, ok := config.SIGNATURE_SCHEME_TO_SIZE[config.SIGNATURE_FLAG_TO_SCHEME[rsp.Scheme]]
+	if !ok || len(publicKey) != size {
+		return nil, fmt.Errorf("%w: unexpected public key for scheme %d", ErrInvalidResponse, rsp.Scheme)
+	}
+	s.scheme = rsp.Scheme
+	s.publicKey = publicKey
+
+	return s, nil
+}
+
+func (s *Signer) SignatureScheme() config.Scheme {
+	return s.scheme
+}
+
+func (s *Signer) PublicKeyBytes() []byte {
+	return s.publicKey
+}
+
+// MgoAddress returns the address controlled by the remote key.
+func (s *Signer) MgoAddress() string {
+	return keypair.SignerMgoAddress(s)
+}
+
+func (s *Signer) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var rsp SignResponse
+	err := s.do(ctx, http.MethodPost, signPath, SignRequest{Digest: base64.StdEncoding.EncodeToString(digest)}, &rsp)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.StdEncoding.DecodeString(rsp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if len(signature) != 64 {
+		return nil, fmt.Errorf("%w: expected a 64 bytes signature, got %d", ErrInvalidResponse, len(signature))
+	}
+
+	return signature, nil
+}
+
+func (s *Signer) do(ctx context.Context, method, path string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.endpoint+path, reader)
+	if err != nil {
+		return err
+	}
+	for key, values := range s.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	rsp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return fmt.Errorf("remote signer returned %s: %s", rsp.Status, strings.TrimSpace(string(message)))
+	}
+	if err := json.NewDecoder(rsp.Body).Decode(result); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	return nil
+}
+
+// NewHandler serves the remote signer protocol for signer, e.g. in front of a KMS
+// client or as a stand-in in tests.
+func NewHandler(signer keypair.KeySigner) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(publicKeyPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, PublicKeyResponse{
+			Scheme:    signer.SignatureScheme(),
+			PublicKey: base64.StdEncoding.EncodeToString(signer.PublicKeyBytes()),
+		})
+	})
+	mux.HandleFunc(signPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req SignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		digest, err := base64.StdEncoding.DecodeString(req.Digest)
+		if err != nil || len(digest) != digestLength {
+			http.Error(w, "digest must be 32 bytes base64 encoded", http.StatusBadRequest)
+			return
+		}
+		signature, err := signer.SignDigest(r.Context(), digest)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, SignResponse{Signature: base64.StdEncoding.EncodeToString(signature)})
+	})
+
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
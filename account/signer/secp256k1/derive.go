@@ -41,6 +41,11 @@ func DeriveForPath(path string, seed []byte) (*bip32.Key, error) {
 	return key, nil
 }
 
+// IsValidPath reports whether path is a BIP32 path such as `m/54'/938'/0'/0/0`.
+func IsValidPath(path string) bool {
+	return isValidBIP32Path(path)
+}
+
 func isValidBIP32Path(path string) bool {
 	re := regexp.MustCompile(`^m/(54|74)'/938'/\d+'/(\d+/)*\d+$`)
 	return re.MatchString(path)
@@ -75,6 +75,9 @@ func (s *SignerSecp256k1) Sign(message []byte) []byte {
 }
 
 func Verify(publicKey []byte, message []byte, signature []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
 	r := new(secp256k1.ModNScalar)
 	s := new(secp256k1.ModNScalar)
 	if r.SetByteSlice(signature[:32]) {
@@ -85,7 +88,6 @@ func Verify(publicKey []byte, message []byte, signature []byte) bool {
 	}
 	pk, err := secp256k1.ParsePubKey(publicKey)
 	if err != nil {
-		fmt.Println(err)
 		return false
 	}
 	messageHash := sha256.Sum256(message)
@@ -113,5 +115,9 @@ func parseDERSignature(sig []byte) []byte {
 	for len(sBytes) > 0 && sBytes[0] == 0x00 {
 		sBytes = sBytes[1:]
 	}
-	return append(rBytes, sBytes...)
+	// r and s are fixed size in the 64 bytes signature, left padded with zeros.
+	signature := make([]byte, 64)
+	copy(signature[32-len(rBytes):32], rBytes)
+	copy(signature[64-len(sBytes):], sBytes)
+	return signature
 }
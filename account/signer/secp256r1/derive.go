@@ -0,0 +1,117 @@
+package secp256r1
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	FirstHardenedIndex = uint32(0x80000000)
+	seedModifier       = "Nist256p1 seed"
+)
+
+var ErrInvalidPath = errors.New("invalid derivation path")
+
+// Key is a SLIP-10 extended private key on the NIST P-256 curve.
+type Key struct {
+	Key       []byte
+	ChainCode []byte
+}
+
+// DeriveForPath derives the key for a path such as `m/74'/938'/0'/0/0` following SLIP-10.
+func DeriveForPath(path string, seed []byte) (*Key, error) {
+	if !isValidPath(path) {
+		return nil, ErrInvalidPath
+	}
+
+	key, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := strings.Split(path, "/")
+	for _, segment := range segments[1:] {
+		i64, err := strconv.ParseUint(strings.TrimRight(segment, "'"), 10, 31)
+		if err != nil {
+			return nil, err
+		}
+		i := uint32(i64)
+		if strings.HasSuffix(segment, "'") {
+			i += FirstHardenedIndex
+		}
+		key, err = key.Derive(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+func NewMasterKey(seed []byte) (*Key, error) {
+	data := seed
+	for {
+		sum := hmacSHA512([]byte(seedModifier), data)
+		k := new(big.Int).SetBytes(sum[:32])
+		if k.Sign() != 0 && k.Cmp(curve.Params().N) < 0 {
+			return &Key{
+				Key:       sum[:32],
+				ChainCode: sum[32:],
+			}, nil
+		}
+		data = sum
+	}
+}
+
+// Derive derives the child key at index i, hardened when i >= FirstHardenedIndex.
+func (k *Key) Derive(i uint32) (*Key, error) {
+	var data []byte
+	if i >= FirstHardenedIndex {
+		data = append([]byte{0x0}, k.Key...)
+	} else {
+		x, y := curve.ScalarBaseMult(k.Key)
+		data = elliptic.MarshalCompressed(curve, x, y)
+	}
+	data = binary.BigEndian.AppendUint32(data, i)
+
+	n := curve.Params().N
+	parent := new(big.Int).SetBytes(k.Key)
+	for {
+		sum := hmacSHA512(k.ChainCode, data)
+		il := new(big.Int).SetBytes(sum[:32])
+		if il.Cmp(n) < 0 {
+			child := il.Add(il, parent)
+			child.Mod(child, n)
+			if child.Sign() != 0 {
+				return &Key{
+					Key:       child.FillBytes(make([]byte, 32)),
+					ChainCode: sum[32:],
+				}, nil
+			}
+		}
+		data = binary.BigEndian.AppendUint32(append([]byte{0x1}, sum[32:]...), i)
+	}
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	hash := hmac.New(sha512.New, key)
+	hash.Write(data)
+	return hash.Sum(nil)
+}
+
+// IsValidPath reports whether path is a BIP32 path such as `m/74'/938'/0'/0/0`.
+func IsValidPath(path string) bool {
+	return isValidPath(path)
+}
+
+func isValidPath(path string) bool {
+	re := regexp.MustCompile(`^m/74'/938'/\d+'/\d+/\d+$`)
+	return re.MatchString(path)
+}
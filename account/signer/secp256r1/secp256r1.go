@@ -0,0 +1,129 @@
+package secp256r1
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrInvalidPrivateKey = errors.New("invalid secp256r1 private key")
+
+	curve     = elliptic.P256()
+	halfOrder = new(big.Int).Rsh(curve.Params().N, 1)
+)
+
+type SignerSecp256r1 struct {
+	PrivateKey ecdsa.PrivateKey
+	PublicKey  ecdsa.PublicKey
+}
+
+func NewSigner() (*SignerSecp256r1, error) {
+	privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return newSigner(privateKey)
+}
+
+func NewSignerByHex(privatekey string) (signerSecp256r1 *SignerSecp256r1, err error) {
+	if strings.HasPrefix(privatekey, "0x") || strings.HasPrefix(privatekey, "0X") {
+		privatekey = privatekey[2:]
+	}
+	seed, err := hex.DecodeString(privatekey)
+	if err != nil {
+		return nil, err
+	}
+	return NewSignerBySeed(seed)
+}
+
+// NewSignerBySeed creates a signer from the 32 bytes big endian private key scalar.
+func NewSignerBySeed(seed []byte) (signerSecp256r1 *SignerSecp256r1, err error) {
+	if len(seed) != 32 {
+		return nil, ErrInvalidPrivateKey
+	}
+	d := new(big.Int).SetBytes(seed)
+	if d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+	privateKey := &ecdsa.PrivateKey{D: d}
+	privateKey.Curve = curve
+	privateKey.X, privateKey.Y = curve.ScalarBaseMult(seed)
+	return newSigner(privateKey)
+}
+
+func newSigner(privateKey *ecdsa.PrivateKey) (*SignerSecp256r1, error) {
+	return &SignerSecp256r1{
+		PrivateKey: *privateKey,
+		PublicKey:  privateKey.PublicKey,
+	}, nil
+}
+
+func (s SignerSecp256r1) String() string {
+	return fmt.Sprintf("PrivateKey: %s\nPublicKey: %s\n",
+		s.PrivateKeyHex(),
+		s.PublicKeyHex())
+}
+
+func (s *SignerSecp256r1) PrivateKeyHex() string {
+	return "0x" + hex.EncodeToString(s.PrivateKeyBytes())
+}
+func (s *SignerSecp256r1) PrivateKeyBytes() []byte {
+	return s.PrivateKey.D.FillBytes(make([]byte, 32))
+}
+func (s *SignerSecp256r1) PublicKeyHex() string {
+	return "0x" + hex.EncodeToString(s.PublicKeyBytes())
+}
+
+// PublicKeyBytes returns the 33 bytes compressed public key.
+func (s *SignerSecp256r1) PublicKeyBytes() []byte {
+	return elliptic.MarshalCompressed(curve, s.PublicKey.X, s.PublicKey.Y)
+}
+func (s *SignerSecp256r1) PublicBase64Key() string {
+	return base64.StdEncoding.EncodeToString(s.PublicKeyBytes())
+}
+
+// Sign signs the SHA-256 hash of the message and returns the 64 bytes `r || s`
+// signature, with s normalized to the lower half of the curve order.
+func (s *SignerSecp256r1) Sign(message []byte) []byte {
+	messageHash := sha256.Sum256(message)
+	r, sig, err := ecdsa.Sign(rand.Reader, &s.PrivateKey, messageHash[:])
+	if err != nil {
+		return nil
+	}
+	if sig.Cmp(halfOrder) > 0 {
+		sig.Sub(curve.Params().N, sig)
+	}
+
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	sig.FillBytes(signature[32:])
+	return signature
+}
+
+// Verify verifies a 64 bytes `r || s` signature over the SHA-256 hash of the message.
+// Signatures with a high s are rejected.
+func Verify(publicKey []byte, message []byte, signature []byte) bool {
+	if len(signature) != 64 {
+		return false
+	}
+	x, y := elliptic.UnmarshalCompressed(curve, publicKey)
+	if x == nil {
+		return false
+	}
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if s.Cmp(halfOrder) > 0 {
+		return false
+	}
+
+	messageHash := sha256.Sum256(message)
+	return ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, messageHash[:], r, s)
+}
@@ -0,0 +1,101 @@
+// Package verify checks that a serialized signature was made by an expected address,
+// e.g. to log a user in with a signature from their wallet.
+//
+// The keypair package verifies a signature against the public key embedded in it; any
+// key produces a valid signature of its own. The functions of this package also derive
+// the address of that public key and compare it with the address the signature claims
+// to come from.
+package verify
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/account/multisig"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/utils"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid serialized signature")
+	ErrInvalidAddress   = errors.New("invalid expected address")
+)
+
+// Result describes a verified signature. It is only valid if the signature matches the
+// signed bytes and its public key is the one of the expected address.
+type Result struct {
+	Scheme config.Scheme
+	// PublicKey is the public key of the signature, or the BCS encoded multisig public
+	// key for a multisig signature.
+	PublicKey []byte
+	// MgoAddress is the address derived from PublicKey.
+	MgoAddress      string
+	ExpectedAddress string
+	// SignatureValid reports whether the signature matches the signed bytes.
+	SignatureValid bool
+	// AddressMatches reports whether MgoAddress is ExpectedAddress.
+	AddressMatches bool
+	Valid          bool
+}
+
+// PersonalMessage verifies a base64 encoded serialized signature of a personal message,
+// as returned by Keypair.SignPersonalMessage, against the expected address. It returns
+// an error if the signature or the address cannot be parsed, and a Result that is not
+// Valid if the signature does not verify.
+func PersonalMessage(message []byte, signature string, expectedAddress string) (*Result, error) {
+	return verify(signature, expectedAddress, func(sig []byte) bool {
+		return keypair.VerifyPersonalMessage(message, sig)
+	})
+}
+
+// TransactionBlock verifies a base64 encoded serialized signature of BCS transaction
+// data against the expected address, which should be the sender or the gas owner.
+func TransactionBlock(txBytes []byte, signature string, expectedAddress string) (*Result, error) {
+	return verify(signature, expectedAddress, func(sig []byte) bool {
+		return keypair.VerifyTransactionBlock(txBytes, sig)
+	})
+}
+
+func verify(signature string, expectedAddress string, verifySignature func(sig []byte) bool) (*Result, error) {
+	if !utils.IsValidMgoAddress(model.MgoAddress(expectedAddress)) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAddress, expectedAddress)
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	signatureScheme := keypair.GetSignatureScheme(sig)
+	if signatureScheme == "" {
+		return nil, fmt.Errorf("%w: unknown signature scheme", ErrInvalidSignature)
+	}
+
+	result := &Result{
+		Scheme:          config.SIGNATURE_SCHEME_TO_FLAG[signatureScheme],
+		ExpectedAddress: string(utils.NormalizeMgoAddress(expectedAddress)),
+	}
+	if result.Scheme == config.MultiSigFlag {
+		multiSig, err := multisig.ParseMultiSig(sig)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+		}
+		result.PublicKey = multiSig.PublicKey.Marshal()
+		result.MgoAddress = multiSig.MgoAddress()
+	} else {
+		info := keypair.ParseSignatureInfo(sig, signatureScheme)
+		if info == nil {
+			return nil, fmt.Errorf("%w: unexpected length %d for %s", ErrInvalidSignature, len(sig), signatureScheme)
+		}
+		result.PublicKey = info.PublicKey
+		if result.MgoAddress, err = keypair.PublicKeyToMgoAddress(info.PublicKey, result.Scheme); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+		}
+	}
+
+	result.SignatureValid = verifySignature(sig)
+	result.AddressMatches = result.MgoAddress == result.ExpectedAddress
+	result.Valid = result.SignatureValid && result.AddressMatches
+	return result, nil
+}
@@ -37,7 +37,16 @@ func (d *Decoder) decode(v reflect.Value) (int, error) {
 		return 0, nil
 	}
 
+	if v.Kind() != reflect.Pointer && v.CanAddr() {
+		if i, isUnmarshaler := v.Addr().Interface().(Unmarshaler); isUnmarshaler {
+			return i.UnmarshalBCS(d.reader)
+		}
+	}
 	if i, isUnmarshaler := v.Interface().(Unmarshaler); isUnmarshaler {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+			i = v.Interface().(Unmarshaler)
+		}
 		return i.UnmarshalBCS(d.reader)
 	}
 
@@ -226,8 +235,17 @@ func (d *Decoder) decodeEnum(v reflect.Value) (int, error) {
 		return n, err
 	}
 
+	if enumId >= v.NumField() {
+		return n, fmt.Errorf("enum variant %d out of range for %s", enumId, v.Type().String())
+	}
 	field := v.Field(enumId)
 
+	// variants typed as a nil interface carry no data, e.g. GasCoin or None
+	if field.Kind() == reflect.Interface && field.IsNil() {
+		field.Set(reflect.ValueOf(struct{}{}))
+		return n, nil
+	}
+
 	k, err := d.decode(field)
 	n += k
 
@@ -41,6 +41,11 @@ func (e *Encoder) encode(v reflect.Value) error {
 	}
 
 	i := v.Interface()
+	if v.Kind() != reflect.Pointer && v.CanAddr() {
+		if m, ismarshaler := v.Addr().Interface().(Marshaler); ismarshaler {
+			i = m
+		}
+	}
 	if m, ismarshaler := i.(Marshaler); ismarshaler {
 		bytes, err := m.MarshalBCS()
 		if err != nil {
@@ -126,7 +131,7 @@ func (e *Encoder) encodeEnum(v reflect.Value) error {
 			if fieldKind == reflect.Pointer {
 				return e.encode(reflect.Indirect(field))
 			} else {
-				return e.encode(v)
+				return e.encode(field.Elem())
 			}
 		}
 	}
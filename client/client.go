@@ -18,6 +18,19 @@ func NewMgoClient(rpcUrl string) *Client {
 	return newClient(conn)
 }
 
+// NewMgoClientWithEndpoints instantiates a new Client that fails over between the
+// given full node RPC URLs, preferring them in order.
+func NewMgoClientWithEndpoints(rpcUrls ...string) *Client {
+	conn := httpconn.NewMultiEndpointHttpConn(rpcUrls, nil)
+	return newClient(conn)
+}
+
+// NewMgoClientWithHttpConn instantiates a new Client on a configured connection, e.g.
+// one with a custom retry policy or rate limiter.
+func NewMgoClientWithHttpConn(conn *httpconn.HttpConn) *Client {
+	return newClient(conn)
+}
+
 // newClient creates a new Client with the given http connection and net identity.
 //
 // The connection is used to send the RPC requests to the full node, and the net
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/model/response"
+
+	"github.com/tidwall/gjson"
+)
+
+var ErrBatchNotSent = errors.New("batch not sent")
+
+// BatchResult is the result of a call queued on a Batch. It is set by Batch.Send.
+type BatchResult[T any] struct {
+	Result T
+	Err    error
+}
+
+func (r *BatchResult[T]) decode(rsp httpconn.BatchResponse) {
+	if rsp.Err != nil {
+		r.Err = rsp.Err
+		return
+	}
+	r.Err = json.Unmarshal([]byte(gjson.ParseBytes(rsp.Body).Get("result").Raw), &r.Result)
+}
+
+func (r *BatchResult[T]) fail(err error) {
+	r.Err = err
+}
+
+type batchResult interface {
+	decode(rsp httpconn.BatchResponse)
+	fail(err error)
+}
+
+// Batch queues typed read calls and sends them in a single JSON-RPC 2.0 batch request.
+//
+//	batch := cli.NewBatch()
+//	object := batch.MgoGetObject(request.MgoGetObjectRequest{ObjectId: id})
+//	balance := batch.MgoXGetBalance(request.MgoXGetBalanceRequest{Owner: owner})
+//	err := batch.Send(ctx)
+//	// object.Result, object.Err, balance.Result, balance.Err
+type Batch struct {
+	conn    *httpconn.HttpConn
+	ops     []httpconn.Operation
+	results []batchResult
+	maxSize int
+}
+
+// NewBatch returns an empty batch for the client.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{conn: c.conn}
+}
+
+// SetMaxSize splits batches of more than size calls into several requests, for nodes
+// that limit the size of a batch. Zero sends every batch in one request.
+func (b *Batch) SetMaxSize(size int) *Batch {
+	b.maxSize = size
+	return b
+}
+
+// Len returns the number of queued calls.
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+// BatchCall queues a call of any method on the batch, decoding its result into T.
+func BatchCall[T any](b *Batch, method string, params ...interface{}) *BatchResult[T] {
+	if params == nil {
+		params = []interface{}{}
+	}
+	result := &BatchResult[T]{Err: ErrBatchNotSent}
+	b.ops = append(b.ops, httpconn.Operation{Method: method, Params: params})
+	b.results = append(b.results, result)
+	return result
+}
+
+// Send sends the queued calls and sets their results, then empties the batch so it
+// can be reused. The returned error is set when a request failed as a whole; the
+// results of its calls carry the same error.
+func (b *Batch) Send(ctx context.Context) error {
+	ops, results := b.ops, b.results
+	b.ops, b.results = nil, nil
+
+	size := b.maxSize
+	if size <= 0 {
+		size = len(ops)
+	}
+	var sendErr error
+	for start := 0; start < len(ops); start += size {
+		end := min(start+size, len(ops))
+		responses, err := b.conn.BatchRequest(ctx, ops[start:end])
+		if err != nil {
+			for _, result := range results[start:end] {
+				result.fail(err)
+			}
+			sendErr = err
+			continue
+		}
+		for i, rsp := range responses {
+			results[start+i].decode(rsp)
+		}
+	}
+	return sendErr
+}
+
+// MgoGetObject queues the method `mgo_getObject`.
+func (b *Batch) MgoGetObject(req request.MgoGetObjectRequest) *BatchResult[response.MgoObjectResponse] {
+	return BatchCall[response.MgoObjectResponse](b, "mgo_getObject", req.ObjectId, req.Options)
+}
+
+// MgoMultiGetObjects queues the method `mgo_multiGetObjects`.
+func (b *Batch) MgoMultiGetObjects(req request.MgoMultiGetObjectsRequest) *BatchResult[[]*response.MgoObjectResponse] {
+	return BatchCall[[]*response.MgoObjectResponse](b, "mgo_multiGetObjects", req.ObjectIds, req.Options)
+}
+
+// MgoGetTransactionBlock queues the method `mgo_getTransactionBlock`.
+func (b *Batch) MgoGetTransactionBlock(req request.MgoGetTransactionBlockRequest) *BatchResult[response.MgoTransactionBlockResponse] {
+	return BatchCall[response.MgoTransactionBlockResponse](b, "mgo_getTransactionBlock", req.Digest, req.Options)
+}
+
+// MgoGetCheckpoint queues the method `mgo_getCheckpoint`.
+func (b *Batch) MgoGetCheckpoint(req request.MgoGetCheckpointRequest) *BatchResult[response.CheckpointResponse] {
+	return BatchCall[response.CheckpointResponse](b, "mgo_getCheckpoint", req.CheckpointID)
+}
+
+// MgoXGetBalance queues the method `mgox_getBalance`.
+func (b *Batch) MgoXGetBalance(req request.MgoXGetBalanceRequest) *BatchResult[response.CoinBalanceResponse] {
+	return BatchCall[response.CoinBalanceResponse](b, "mgox_getBalance", req.Owner, req.CoinType)
+}
+
+// MgoXGetAllBalance queues the method `mgox_getAllBalances`.
+func (b *Batch) MgoXGetAllBalance(req request.MgoXGetAllBalanceRequest) *BatchResult[response.CoinAllBalanceResponse] {
+	return BatchCall[response.CoinAllBalanceResponse](b, "mgox_getAllBalances", req.Owner)
+}
+
+// MgoXGetCoinMetadata queues the method `mgox_getCoinMetadata`.
+func (b *Batch) MgoXGetCoinMetadata(req request.MgoXGetCoinMetadataRequest) *BatchResult[response.CoinMetadataResponse] {
+	return BatchCall[response.CoinMetadataResponse](b, "mgox_getCoinMetadata", req.CoinType)
+}
@@ -3,8 +3,6 @@ package client
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"log"
 
 	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
 	"github.com/mangonet-labs/mgo-go-sdk/client/wsconn"
@@ -18,6 +16,18 @@ type ISubscribeAPI interface {
 	SubscribeEvent(ctx context.Context, req request.MgoSubscribeEventsRequest, msgCh chan response.MgoEventResponse) error
 	SubscribeTransaction(ctx context.Context, req request.MgoSubscribeTransactionsRequest, msgCh chan response.MgoEffects) error
 }
+
+// ISubscriptionAPI subscribes like ISubscribeAPI and returns the subscriptions. The
+// clients returned by NewMgoWebsocketClient and its variants implement it:
+//
+//	sub, err := ws.(client.ISubscriptionAPI).NewEventSubscription(ctx, req, events)
+type ISubscriptionAPI interface {
+	NewEventSubscription(ctx context.Context, req request.MgoSubscribeEventsRequest, msgCh chan response.MgoEventResponse) (*Subscription, error)
+	NewTransactionSubscription(ctx context.Context, req request.MgoSubscribeTransactionsRequest, msgCh chan response.MgoEffects) (*Subscription, error)
+}
+
+// Subscription is a websocket subscription, see wsconn.Subscription.
+type Subscription = wsconn.Subscription
 type mgoSubscribeImpl struct {
 	conn *wsconn.WsConn
 }
@@ -35,9 +45,6 @@ func (c *Client) MgoXGetDynamicFieldObject(ctx context.Context, req request.MgoX
 	if err != nil {
 		return rsp, err
 	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
-	}
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").String()), &rsp)
 	if err != nil {
 		return rsp, err
@@ -62,9 +69,6 @@ func (c *Client) MgoXGetDynamicFields(ctx context.Context, req request.MgoXGetDy
 	if err != nil {
 		return rsp, err
 	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
-	}
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").String()), &rsp)
 	if err != nil {
 		return rsp, err
@@ -90,9 +94,6 @@ func (c *Client) MgoXGetOwnedObjects(ctx context.Context, req request.MgoXGetOwn
 	if err != nil {
 		return rsp, err
 	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
-	}
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").String()), &rsp)
 	if err != nil {
 		return rsp, err
@@ -106,6 +107,9 @@ func (c *Client) MgoXQueryEvents(ctx context.Context, req request.MgoXQueryEvent
 	if err := validate.ValidateStruct(req); err != nil {
 		return rsp, err
 	}
+	if err := validate.ValidateFilter(req.MgoEventFilter); err != nil {
+		return rsp, err
+	}
 	respBytes, err := c.conn.Request(ctx, httpconn.Operation{
 		Method: "mgox_queryEvents",
 		Params: []interface{}{
@@ -118,9 +122,6 @@ func (c *Client) MgoXQueryEvents(ctx context.Context, req request.MgoXQueryEvent
 	if err != nil {
 		return rsp, err
 	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
-	}
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").String()), &rsp)
 	if err != nil {
 		return rsp, err
@@ -134,6 +135,9 @@ func (c *Client) MgoXQueryTransactionBlocks(ctx context.Context, req request.Mgo
 	if err := validate.ValidateStruct(req); err != nil {
 		return rsp, err
 	}
+	if err := validate.ValidateFilter(req.MgoTransactionBlockResponseQuery.TransactionFilter); err != nil {
+		return rsp, err
+	}
 	respBytes, err := c.conn.Request(ctx, httpconn.Operation{
 		Method: "mgox_queryTransactionBlocks",
 		Params: []interface{}{
@@ -146,9 +150,6 @@ func (c *Client) MgoXQueryTransactionBlocks(ctx context.Context, req request.Mgo
 	if err != nil {
 		return rsp, err
 	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
-	}
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").Raw), &rsp)
 	if err != nil {
 		return rsp, err
@@ -167,9 +168,6 @@ func (c *Client) MgoXResolveNameServiceAddress(ctx context.Context, req request.
 	if err != nil {
 		return "", err
 	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return "", errors.New(gjson.ParseBytes(respBytes).Get("error").String())
-	}
 
 	return gjson.ParseBytes(respBytes).Get("result").String(), nil
 }
@@ -188,9 +186,6 @@ func (c *Client) MgoXResolveNameServiceNames(ctx context.Context, req request.Mg
 	if err != nil {
 		return rsp, err
 	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
-	}
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").String()), &rsp)
 	if err != nil {
 		return rsp, err
@@ -199,71 +194,57 @@ func (c *Client) MgoXResolveNameServiceNames(ctx context.Context, req request.Mg
 }
 
 // SubscribeEvent implements the method `mgox_subscribeEvent`, subscribe to a stream of Mgo event.
+// The subscription is renewed when the connection breaks and runs until ctx is done.
 func (s *mgoSubscribeImpl) SubscribeEvent(ctx context.Context, req request.MgoSubscribeEventsRequest, msgCh chan response.MgoEventResponse) error {
-	rsp := make(chan []byte, 10)
-	err := s.conn.Call(ctx, wsconn.CallOp{
+	_, err := s.NewEventSubscription(ctx, req, msgCh)
+	return err
+}
+
+// SubscribeTransaction implements the method `mgox_subscribeTransaction`, subscribe to a stream of Mgo transaction effects.
+// The subscription is renewed when the connection breaks and runs until ctx is done.
+func (s *mgoSubscribeImpl) SubscribeTransaction(ctx context.Context, req request.MgoSubscribeTransactionsRequest, msgCh chan response.MgoEffects) error {
+	_, err := s.NewTransactionSubscription(ctx, req, msgCh)
+	return err
+}
+
+// NewEventSubscription subscribes to a stream of Mgo events like SubscribeEvent, and returns the
+// subscription to get its ID and errors, or to end it with `mgox_unsubscribeEvent`.
+func (s *mgoSubscribeImpl) NewEventSubscription(ctx context.Context, req request.MgoSubscribeEventsRequest, msgCh chan response.MgoEventResponse) (*Subscription, error) {
+	if err := validate.ValidateFilter(req.MgoEventFilter); err != nil {
+		return nil, err
+	}
+	return subscribe(ctx, s.conn, wsconn.CallOp{
 		Method: "mgox_subscribeEvent",
 		Params: []interface{}{
 			req.MgoEventFilter,
 		},
-	}, rsp)
-	if err != nil {
-		return err
-	}
-
-	go func() {
-		for {
-			select {
-			case messageData := <-rsp:
-				var result response.MgoEventResponse
-				if gjson.ParseBytes(messageData).Get("error").Exists() {
-					log.Fatal(gjson.ParseBytes(messageData).Get("error").String())
-				}
-
-				err := json.Unmarshal([]byte(gjson.ParseBytes(messageData).Get("params.result").String()), &result)
-				if err != nil {
-					log.Fatal(err)
-				}
-
-				msgCh <- result
-			}
-		}
-	}()
-
-	return nil
+	}, "mgox_unsubscribeEvent", msgCh)
 }
 
-// SubscribeTransaction implements the method `mgox_subscribeTransaction`, subscribe to a stream of Mgo transaction effects.
-func (s *mgoSubscribeImpl) SubscribeTransaction(ctx context.Context, req request.MgoSubscribeTransactionsRequest, msgCh chan response.MgoEffects) error {
-	rsp := make(chan []byte, 10)
-	err := s.conn.Call(ctx, wsconn.CallOp{
+// NewTransactionSubscription subscribes to a stream of Mgo transaction effects like SubscribeTransaction, and returns
+// the subscription to get its ID and errors, or to end it with `mgox_unsubscribeTransaction`.
+func (s *mgoSubscribeImpl) NewTransactionSubscription(ctx context.Context, req request.MgoSubscribeTransactionsRequest, msgCh chan response.MgoEffects) (*Subscription, error) {
+	if err := validate.ValidateFilter(req.TransactionFilter); err != nil {
+		return nil, err
+	}
+	return subscribe(ctx, s.conn, wsconn.CallOp{
 		Method: "mgox_subscribeTransaction",
 		Params: []interface{}{
 			req.TransactionFilter,
 		},
-	}, rsp)
-	if err != nil {
-		return err
-	}
-
-	go func() {
-		for {
-			select {
-			case messageData := <-rsp:
-				var result response.MgoEffects
-				if gjson.ParseBytes(messageData).Get("error").Exists() {
-					log.Fatal(gjson.ParseBytes(messageData).Get("error").String())
-				}
-
-				err := json.Unmarshal([]byte(gjson.ParseBytes(messageData).Get("params.result").String()), &result)
-				if err != nil {
-					log.Fatal(err)
-				}
+	}, "mgox_unsubscribeTransaction", msgCh)
+}
 
-				msgCh <- result
-			}
+func subscribe[T any](ctx context.Context, conn *wsconn.WsConn, op wsconn.CallOp, unsubscribeMethod string, msgCh chan T) (*Subscription, error) {
+	return conn.Subscribe(ctx, op, unsubscribeMethod, func(ctx context.Context, result json.RawMessage) error {
+		var msg T
+		if err := json.Unmarshal(result, &msg); err != nil {
+			return err
 		}
-	}()
-
-	return nil
+		select {
+		case msgCh <- msg:
+		case <-ctx.Done():
+		}
+		return nil
+	})
 }
@@ -3,7 +3,6 @@ package client
 import (
 	"context"
 	"encoding/json"
-	"errors"
 
 	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
 	"github.com/mangonet-labs/mgo-go-sdk/model/request"
@@ -24,10 +23,7 @@ func (c *Client) MgoGetMoveFunctionArgTypes(ctx context.Context, req request.Get
 		},
 	})
 	if err != nil {
-		return rsp, nil
-	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
+		return rsp, err
 	}
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").String()), &rsp)
 	if err != nil {
@@ -48,10 +44,7 @@ func (c *Client) MgoGetNormalizedMoveFunction(ctx context.Context, req request.G
 		},
 	})
 	if err != nil {
-		return rsp, nil
-	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
+		return rsp, err
 	}
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").String()), &rsp)
 	if err != nil {
@@ -71,10 +64,7 @@ func (c *Client) MgoGetNormalizedMoveModule(ctx context.Context, req request.Get
 		},
 	})
 	if err != nil {
-		return rsp, nil
-	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
+		return rsp, err
 	}
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").String()), &rsp)
 	if err != nil {
@@ -93,10 +83,7 @@ func (c *Client) MgoGetNormalizedMoveModulesByPackage(ctx context.Context, req r
 		},
 	})
 	if err != nil {
-		return rsp, nil
-	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
+		return rsp, err
 	}
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").String()), &rsp)
 	if err != nil {
@@ -117,10 +104,7 @@ func (c *Client) MgoGetNormalizedMoveStruct(ctx context.Context, req request.Get
 		},
 	})
 	if err != nil {
-		return rsp, nil
-	}
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
+		return rsp, err
 	}
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").String()), &rsp)
 	if err != nil {
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"errors"
 
 	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
 
@@ -20,8 +19,5 @@ func (c *Client) MgoCall(ctx context.Context, method string, params ...interface
 	if err != nil {
 		return nil, err
 	}
-	if gjson.ParseBytes(resp).Get("error").Exists() {
-		return nil, errors.New(gjson.ParseBytes(resp).Get("error").String())
-	}
 	return gjson.ParseBytes(resp).String(), nil
 }
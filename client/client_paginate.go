@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/model/response"
+)
+
+// Seq2 is an iterator over pairs, with the shape of iter.Seq2 so it can be ranged over
+// with Go 1.23:
+//
+//	for coin, err := range cli.MgoXGetAllCoinsIter(ctx, req, 0) {
+//		if err != nil {
+//			return err
+//		}
+//		// use coin
+//	}
+//
+// Before Go 1.23, call it with a yield function that returns false to stop.
+type Seq2[K, V any] func(yield func(K, V) bool)
+
+// Collect returns the items of seq, or the first error it yields.
+func Collect[T any](seq Seq2[T, error]) ([]T, error) {
+	var items []T
+	var err error
+	seq(func(item T, e error) bool {
+		if e != nil {
+			err = e
+			return false
+		}
+		items = append(items, item)
+		return true
+	})
+	return items, err
+}
+
+type pageFunc[T any] func(ctx context.Context, cursor interface{}) (items []T, next interface{}, hasNextPage bool, err error)
+
+// paginate walks the pages returned by fetch lazily, starting at cursor. It stops after
+// maxItems items unless maxItems is zero, and yields a zero item with the error of a
+// failed request or of ctx.
+func paginate[T any](ctx context.Context, cursor interface{}, maxItems int, fetch pageFunc[T]) Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		count := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			items, next, hasNextPage, err := fetch(ctx, cursor)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				count++
+				if maxItems > 0 && count >= maxItems {
+					return
+				}
+			}
+			if !hasNextPage || len(items) == 0 {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// MgoXGetCoinsIter iterates over all coins of req.CoinType owned by req.Owner, fetching
+// pages of req.Limit coins with MgoXGetCoins as needed. A maxItems of zero iterates over
+// all coins.
+func (c *Client) MgoXGetCoinsIter(ctx context.Context, req request.MgoXGetCoinsRequest, maxItems int) Seq2[response.CoinData, error] {
+	return paginate(ctx, req.Cursor, maxItems, func(ctx context.Context, cursor interface{}) ([]response.CoinData, interface{}, bool, error) {
+		req.Cursor = cursor
+		page, err := c.MgoXGetCoins(ctx, req)
+		return page.Data, page.NextCursor, page.HasNextPage, err
+	})
+}
+
+// MgoXGetAllCoinsIter iterates over all coins owned by req.Owner, fetching pages with
+// MgoXGetAllCoins as needed. A maxItems of zero iterates over all coins.
+func (c *Client) MgoXGetAllCoinsIter(ctx context.Context, req request.MgoXGetAllCoinsRequest, maxItems int) Seq2[response.CoinData, error] {
+	return paginate(ctx, req.Cursor, maxItems, func(ctx context.Context, cursor interface{}) ([]response.CoinData, interface{}, bool, error) {
+		req.Cursor = cursor
+		page, err := c.MgoXGetAllCoins(ctx, req)
+		return page.Data, page.NextCursor, page.HasNextPage, err
+	})
+}
+
+// MgoXGetOwnedObjectsIter iterates over all objects owned by req.Address, fetching pages
+// with MgoXGetOwnedObjects as needed. A maxItems of zero iterates over all objects.
+func (c *Client) MgoXGetOwnedObjectsIter(ctx context.Context, req request.MgoXGetOwnedObjectsRequest, maxItems int) Seq2[response.MgoObjectResponse, error] {
+	return paginate(ctx, req.Cursor, maxItems, func(ctx context.Context, cursor interface{}) ([]response.MgoObjectResponse, interface{}, bool, error) {
+		req.Cursor = cursor
+		page, err := c.MgoXGetOwnedObjects(ctx, req)
+		return page.Data, page.NextCursor, page.HasNextPage, err
+	})
+}
+
+// MgoXGetDynamicFieldsIter iterates over all dynamic fields of req.ObjectId, fetching
+// pages with MgoXGetDynamicFields as needed. A maxItems of zero iterates over all fields.
+func (c *Client) MgoXGetDynamicFieldsIter(ctx context.Context, req request.MgoXGetDynamicFieldsRequest, maxItems int) Seq2[response.DynamicFieldInfo, error] {
+	return paginate(ctx, req.Cursor, maxItems, func(ctx context.Context, cursor interface{}) ([]response.DynamicFieldInfo, interface{}, bool, error) {
+		req.Cursor = cursor
+		page, err := c.MgoXGetDynamicFields(ctx, req)
+		return page.Data, page.NextCursor, page.HasNextPage, err
+	})
+}
+
+// MgoXQueryEventsIter iterates over all events matching req.MgoEventFilter, fetching
+// pages with MgoXQueryEvents as needed. A maxItems of zero iterates over all events.
+func (c *Client) MgoXQueryEventsIter(ctx context.Context, req request.MgoXQueryEventsRequest, maxItems int) Seq2[response.MgoEventResponse, error] {
+	return paginate(ctx, req.Cursor, maxItems, func(ctx context.Context, cursor interface{}) ([]response.MgoEventResponse, interface{}, bool, error) {
+		req.Cursor = cursor
+		page, err := c.MgoXQueryEvents(ctx, req)
+		return page.Data, page.NextCursor, page.HasNextPage, err
+	})
+}
+
+// MgoXQueryTransactionBlocksIter iterates over all transactions matching the query of
+// req, fetching pages with MgoXQueryTransactionBlocks as needed. A maxItems of zero
+// iterates over all transactions.
+func (c *Client) MgoXQueryTransactionBlocksIter(ctx context.Context, req request.MgoXQueryTransactionBlocksRequest, maxItems int) Seq2[response.MgoTransactionBlockResponse, error] {
+	return paginate(ctx, req.Cursor, maxItems, func(ctx context.Context, cursor interface{}) ([]response.MgoTransactionBlockResponse, interface{}, bool, error) {
+		req.Cursor = cursor
+		page, err := c.MgoXQueryTransactionBlocks(ctx, req)
+		return page.Data, page.NextCursor, page.HasNextPage, err
+	})
+}
+
+// MgoGetCheckpointsIter iterates over checkpoints, fetching pages with MgoGetCheckpoints
+// as needed. A maxItems of zero iterates over all checkpoints.
+func (c *Client) MgoGetCheckpointsIter(ctx context.Context, req request.MgoGetCheckpointsRequest, maxItems int) Seq2[response.CheckpointResponse, error] {
+	return paginate(ctx, req.Cursor, maxItems, func(ctx context.Context, cursor interface{}) ([]response.CheckpointResponse, interface{}, bool, error) {
+		req.Cursor = cursor
+		page, err := c.MgoGetCheckpoints(ctx, req)
+		return page.Data, page.NextCursor, page.HasNextPage, err
+	})
+}
+
+// MgoXResolveNameServiceNamesIter iterates over all names of req.Address, fetching pages
+// with MgoXResolveNameServiceNames as needed. A maxItems of zero iterates over all names.
+func (c *Client) MgoXResolveNameServiceNamesIter(ctx context.Context, req request.MgoXResolveNameServiceNamesRequest, maxItems int) Seq2[string, error] {
+	return paginate(ctx, req.Cursor, maxItems, func(ctx context.Context, cursor interface{}) ([]string, interface{}, bool, error) {
+		req.Cursor = cursor
+		page, err := c.MgoXResolveNameServiceNames(ctx, req)
+		return page.Data, page.NextCursor, page.HasNextPage, err
+	})
+}
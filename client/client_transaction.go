@@ -3,8 +3,8 @@ package client
 import (
 	"context"
 	"encoding/json"
-	"errors"
 
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
 	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
 	"github.com/mangonet-labs/mgo-go-sdk/model/request"
 	"github.com/mangonet-labs/mgo-go-sdk/model/response"
@@ -17,7 +17,7 @@ import (
 func (c *Client) SignAndExecuteTransactionBlock(ctx context.Context, req request.SignAndExecuteTransactionBlockRequest) (response.MgoTransactionBlockResponse, error) {
 	var rsp response.MgoTransactionBlockResponse
 
-	signedTxn, err := req.Keypair.SignTransactionBlock(&req.TxnMetaData)
+	signedTxn, err := keypair.SignTransactionBlock(ctx, req.Keypair, &req.TxnMetaData)
 	if err != nil {
 		return rsp, err
 	}
@@ -35,10 +35,6 @@ func (c *Client) SignAndExecuteTransactionBlock(ctx context.Context, req request
 		return rsp, err
 	}
 
-	if gjson.ParseBytes(respBytes).Get("error").Exists() {
-		return rsp, errors.New(gjson.ParseBytes(respBytes).Get("error").String())
-	}
-
 	err = json.Unmarshal([]byte(gjson.ParseBytes(respBytes).Get("result").String()), &rsp)
 	if err != nil {
 		return rsp, err
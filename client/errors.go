@@ -0,0 +1,24 @@
+package client
+
+import (
+	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
+)
+
+// RPCError is the error object of a JSON-RPC response. Use errors.As to get the code,
+// message and data of a failed call.
+type RPCError = httpconn.RPCError
+
+// HTTPError is returned when the full node answers with a non-2xx HTTP status.
+type HTTPError = httpconn.HTTPError
+
+// Error classes for errors.Is. Any error that is neither an *RPCError nor an
+// *HTTPError nor ErrInvalidResponse comes from the transport.
+var (
+	ErrInvalidResponse   = httpconn.ErrInvalidResponse
+	ErrMethodNotFound    = httpconn.ErrMethodNotFound
+	ErrInvalidParams     = httpconn.ErrInvalidParams
+	ErrObjectNotFound    = httpconn.ErrObjectNotFound
+	ErrInsufficientGas   = httpconn.ErrInsufficientGas
+	ErrObjectEquivocated = httpconn.ErrObjectEquivocated
+	ErrTransient         = httpconn.ErrTransient
+)
@@ -0,0 +1,146 @@
+package httpconn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// JSON-RPC error codes returned by the full node.
+const (
+	CodeParseError                = -32700
+	CodeInvalidRequest            = -32600
+	CodeMethodNotFound            = -32601
+	CodeInvalidParams             = -32602
+	CodeInternalError             = -32603
+	CodeTransactionExecutionError = -32002
+	CodeTransientError            = -32050
+)
+
+var (
+	// ErrInvalidResponse is returned when the node answers with a body that is not a
+	// JSON-RPC response.
+	ErrInvalidResponse = errors.New("invalid JSON-RPC response")
+
+	ErrMethodNotFound    = errors.New("method not found")
+	ErrInvalidParams     = errors.New("invalid params")
+	ErrObjectNotFound    = errors.New("object not found")
+	ErrInsufficientGas   = errors.New("insufficient gas")
+	ErrObjectEquivocated = errors.New("object equivocated")
+	ErrTransient         = errors.New("transient error")
+)
+
+// RPCError is the error object of a JSON-RPC response.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	if len(e.Data) > 0 {
+		return fmt.Sprintf("rpc error %d: %s: %s", e.Code, e.Message, e.Data)
+	}
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// errorClasses are the JSON-RPC error codes with which the node reports an error class,
+// and the messages it uses for it: the text of its user input errors and the names of
+// the variants of its execution errors, matched case-sensitively.
+var errorClasses = map[error]struct {
+	codes    []int
+	messages []string
+}{
+	ErrObjectNotFound: {
+		codes:    []int{CodeInvalidParams, CodeTransactionExecutionError},
+		messages: []string{"Could not find the referenced object", "ObjectNotFound {"},
+	},
+	ErrInsufficientGas: {
+		codes:    []int{CodeInvalidParams, CodeTransactionExecutionError},
+		messages: []string{"is lower than the needed amount", "InsufficientGas", "GasBalanceTooLow"},
+	},
+	ErrObjectEquivocated: {
+		codes:    []int{CodeTransactionExecutionError},
+		messages: []string{"ObjectLockConflict", "equivocated until the next epoch", "already locked by a different transaction"},
+	},
+}
+
+// Is reports whether the error belongs to one of the error classes of this package,
+// so callers can use errors.Is(err, httpconn.ErrObjectNotFound) and the like.
+func (e *RPCError) Is(target error) bool {
+	switch target {
+	case ErrMethodNotFound:
+		return e.Code == CodeMethodNotFound
+	case ErrInvalidParams:
+		return e.Code == CodeInvalidParams
+	case ErrTransient:
+		return e.Code == CodeTransientError
+	}
+	class, ok := errorClasses[target]
+	if !ok || !slices.Contains(class.codes, e.Code) {
+		return false
+	}
+	for _, message := range class.messages {
+		if strings.Contains(e.Message, message) || strings.Contains(string(e.Data), message) {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPError is returned when the node answers with a non-2xx HTTP status.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *HTTPError) Error() string {
+	body := strings.TrimSpace(string(e.Body))
+	if len(body) > 256 {
+		body = body[:256] + "..."
+	}
+	if body == "" {
+		return fmt.Sprintf("http error: %s", e.Status)
+	}
+	return fmt.Sprintf("http error: %s: %s", e.Status, body)
+}
+
+// ParseRPCError returns the *RPCError carried by a JSON-RPC response body, or nil if
+// the response has no error.
+func ParseRPCError(body []byte) error {
+	value := gjson.GetBytes(body, "error")
+	if !value.Exists() || value.Type == gjson.Null {
+		return nil
+	}
+
+	rpcErr := &RPCError{}
+	if !value.IsObject() || json.Unmarshal([]byte(value.Raw), rpcErr) != nil {
+		rpcErr = &RPCError{Message: value.String()}
+	}
+	return rpcErr
+}
+
+// checkResponse turns a response that is not a successful HTTP response with a JSON
+// body into an error.
+func checkResponse(statusCode int, status string, body []byte) error {
+	if statusCode < 200 || statusCode > 299 {
+		return &HTTPError{StatusCode: statusCode, Status: status, Body: body}
+	}
+	if !gjson.ValidBytes(body) {
+		return fmt.Errorf("%w: %.256s", ErrInvalidResponse, body)
+	}
+	return nil
+}
+
+// checkRPCResponse returns the error of a single JSON-RPC response.
+func checkRPCResponse(body []byte) error {
+	if !gjson.ParseBytes(body).IsObject() {
+		return fmt.Errorf("%w: %.256s", ErrInvalidResponse, body)
+	}
+	return ParseRPCError(body)
+}
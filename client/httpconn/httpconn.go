@@ -4,23 +4,101 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
-
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mangonet-labs/mgo-go-sdk/model/request"
 
+	"github.com/tidwall/gjson"
 	"golang.org/x/time/rate"
 )
 
-const defaultTimeout = time.Second * 5
+const (
+	defaultTimeout        = time.Second * 5
+	defaultHealthCooldown = time.Second * 5
+	maxHealthCooldown     = time.Minute
+)
+
+// writeMethods are the methods that change the chain state. They are only retried when
+// the request certainly did not reach a node, see Request.
+var writeMethods = map[string]bool{
+	"mgo_executeTransactionBlock": true,
+}
+
+// RetryPolicy configures how failed requests are retried. A request is attempted at
+// most MaxAttempts times; the wait before attempt n+1 is InitialBackoff*Multiplier^(n-1),
+// capped at MaxBackoff and randomized by ±Jitter (a fraction of the wait).
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+}
+
+// DefaultRetryPolicy is the policy of a new HttpConn.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// NoRetry disables retries and failover.
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// Backoff returns the randomized wait after the given failed attempt, starting at 1.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	wait := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		wait *= p.Multiplier
+	}
+	if p.MaxBackoff > 0 && wait > float64(p.MaxBackoff) {
+		wait = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		wait += wait * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(wait)
+}
+
+type endpoint struct {
+	url            string
+	failures       int
+	unhealthyUntil time.Time
+}
+
+// EndpointStatus is the health of one RPC endpoint.
+type EndpointStatus struct {
+	URL                 string
+	Healthy             bool
+	ConsecutiveFailures int
+	UnhealthyUntil      time.Time
+}
 
 type HttpConn struct {
-	c       *http.Client
-	rl      *rate.Limiter
-	rpcUrl  string
-	timeout time.Duration
+	id        atomic.Int64
+	c         *http.Client
+	rl        *rate.Limiter
+	endpoints []*endpoint
+	mu        sync.Mutex
+	timeout   time.Duration
+	retry     RetryPolicy
+	cooldown  time.Duration
+
+	header               http.Header
+	headerFunc           func(ctx context.Context) http.Header
+	userAgent            string
+	requestInterceptors  []RequestInterceptor
+	responseInterceptors []ResponseInterceptor
 }
 
 // newDefaultRateLimiter returns a new rate.Limiter with a rate of 10000 requests
@@ -33,27 +111,94 @@ func newDefaultRateLimiter() *rate.Limiter {
 // NewHttpConn creates a new HttpConn with the specified RPC URL.
 // It initializes the HTTP client and sets the default timeout for requests.
 func NewHttpConn(rpcUrl string) *HttpConn {
-	return &HttpConn{
-		c:       &http.Client{},
-		rpcUrl:  rpcUrl,
-		timeout: defaultTimeout,
-	}
+	return NewMultiEndpointHttpConn([]string{rpcUrl}, &http.Client{})
 }
 
 // NewCustomHttpConn creates a new HttpConn with the specified RPC URL and a custom
 // http.Client. This is useful if you want to set custom timeouts, transport, or other
 // options for the HTTP client.
 func NewCustomHttpConn(rpcUrl string, cli *http.Client) *HttpConn {
+	return NewMultiEndpointHttpConn([]string{rpcUrl}, cli)
+}
+
+// NewMultiEndpointHttpConn creates a new HttpConn that sends requests to the first
+// healthy endpoint of rpcUrls and fails over to the next ones when requests to it fail.
+// An endpoint that failed is skipped for a cooldown that grows with its consecutive
+// failures, until all endpoints are unhealthy.
+func NewMultiEndpointHttpConn(rpcUrls []string, cli *http.Client) *HttpConn {
+	if cli == nil {
+		cli = &http.Client{}
+	}
+	endpoints := make([]*endpoint, len(rpcUrls))
+	for i, rpcUrl := range rpcUrls {
+		endpoints[i] = &endpoint{url: rpcUrl}
+	}
 	return &HttpConn{
-		c:       cli,
-		rpcUrl:  rpcUrl,
-		timeout: defaultTimeout,
+		c:         cli,
+		rl:        newDefaultRateLimiter(),
+		endpoints: endpoints,
+		timeout:   defaultTimeout,
+		retry:     DefaultRetryPolicy,
+		cooldown:  defaultHealthCooldown,
+	}
+}
+
+// SetTimeout sets the timeout of a single attempt of a request. Zero disables it. Writes
+// are not bounded by it, only by their context: a write that timed out may still be
+// executed, so it is not retried, and waiting for its execution can take longer.
+func (h *HttpConn) SetTimeout(timeout time.Duration) *HttpConn {
+	h.timeout = timeout
+	return h
+}
+
+// SetRateLimiter sets the limiter every attempt waits for. Nil disables rate limiting.
+func (h *HttpConn) SetRateLimiter(rl *rate.Limiter) *HttpConn {
+	h.rl = rl
+	return h
+}
+
+// SetRetryPolicy sets the retry policy of the connection.
+func (h *HttpConn) SetRetryPolicy(policy RetryPolicy) *HttpConn {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	h.retry = policy
+	return h
+}
+
+// SetHealthCooldown sets how long an endpoint is skipped after its first failure.
+func (h *HttpConn) SetHealthCooldown(cooldown time.Duration) *HttpConn {
+	h.cooldown = cooldown
+	return h
+}
+
+// Endpoints returns the health of the endpoints in the order they are preferred.
+func (h *HttpConn) Endpoints() []EndpointStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	status := make([]EndpointStatus, len(h.endpoints))
+	for i, e := range h.endpoints {
+		status[i] = EndpointStatus{
+			URL:                 e.url,
+			Healthy:             !now.Before(e.unhealthyUntil),
+			ConsecutiveFailures: e.failures,
+			UnhealthyUntil:      e.unhealthyUntil,
+		}
 	}
+	return status
 }
 
 // Request sends a JSON-RPC request to the server using the specified operation.
 // It constructs a JSON-RPC request payload with the given method and parameters,
-// sends it via HTTP POST to the configured RPC URL, and returns the response body as bytes.
+// sends it via HTTP POST to a healthy endpoint, and returns the response body as bytes.
+//
+// Transport errors, invalid responses, 429 and 5xx statuses and transient RPC errors are
+// retried according to the retry policy, failing over to the next healthy endpoint.
+// Writes such as `mgo_executeTransactionBlock` are only retried when the request did
+// not reach a node: dial errors and 429 or 503 statuses. An ambiguous failure of a
+// write is returned to the caller, who can look the transaction up by its digest
+// before submitting it again.
 //
 // Parameters:
 // - ctx: The context to control cancellation and timeout.
@@ -61,11 +206,13 @@ func NewCustomHttpConn(rpcUrl string, cli *http.Client) *HttpConn {
 //
 // Returns:
 // - []byte: The response body from the server.
-// - error: An error if the request fails or the response cannot be read.
+// - error: An error if the request fails or the response cannot be read, an *HTTPError
+// for a non-2xx status, ErrInvalidResponse for a body that is not JSON, or an *RPCError
+// if the response carries a JSON-RPC error.
 func (h *HttpConn) Request(ctx context.Context, op Operation) ([]byte, error) {
 	jsonRPCReq := request.JsonRPCRequest{
 		JsonRPC: "2.0",
-		ID:      time.Now().UnixMilli(),
+		ID:      h.nextID(),
 		Method:  op.Method,
 		Params:  op.Params,
 	}
@@ -73,22 +220,241 @@ func (h *HttpConn) Request(ctx context.Context, op Operation) ([]byte, error) {
 	if err != nil {
 		return []byte{}, err
 	}
+	return h.do(ctx, []string{op.Method}, reqBytes, writeMethods[op.Method], checkRPCResponse)
+}
 
-	request, err := http.NewRequest("POST", h.rpcUrl, bytes.NewBuffer(reqBytes))
+// BatchResponse is the response to one operation of a batch request.
+type BatchResponse struct {
+	// Body is the JSON-RPC response object of the operation.
+	Body []byte
+	// Err is the *RPCError of the operation, or an error if the node did not answer it.
+	Err error
+}
+
+// BatchRequest sends the operations as a single JSON-RPC 2.0 batch and returns their
+// responses in the order of ops, matched by request ID. The returned error is only set
+// when the batch as a whole failed; errors of single operations are in the responses.
+// A batch containing a write is retried like a write.
+func (h *HttpConn) BatchRequest(ctx context.Context, ops []Operation) ([]BatchResponse, error) {
+	if len(ops) == 0 {
+		return []BatchResponse{}, nil
+	}
+
+	write := false
+	methods := make([]string, len(ops))
+	ids := make(map[int64]int, len(ops))
+	jsonRPCReqs := make([]request.JsonRPCRequest, len(ops))
+	for i, op := range ops {
+		id := h.nextID()
+		ids[id] = i
+		write = write || writeMethods[op.Method]
+		methods[i] = op.Method
+		jsonRPCReqs[i] = request.JsonRPCRequest{
+			JsonRPC: "2.0",
+			ID:      id,
+			Method:  op.Method,
+			Params:  op.Params,
+		}
+	}
+	reqBytes, err := json.Marshal(jsonRPCReqs)
 	if err != nil {
-		return []byte{}, err
+		return nil, err
+	}
+
+	bodyBytes, err := h.do(ctx, methods, reqBytes, write, func(body []byte) error {
+		if !gjson.ParseBytes(body).IsArray() {
+			// A node rejecting the whole batch answers with a single error object.
+			if err := ParseRPCError(body); err != nil {
+				return err
+			}
+			return fmt.Errorf("%w: expected a batch response", ErrInvalidResponse)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]BatchResponse, len(ops))
+	for _, item := range gjson.ParseBytes(bodyBytes).Array() {
+		i, ok := ids[item.Get("id").Int()]
+		if !ok || responses[i].Body != nil {
+			continue
+		}
+		responses[i] = BatchResponse{Body: []byte(item.Raw), Err: checkRPCResponse([]byte(item.Raw))}
+	}
+	for i := range responses {
+		if responses[i].Body == nil {
+			responses[i].Err = fmt.Errorf("%w: no response for %s", ErrInvalidResponse, ops[i].Method)
+		}
+	}
+	return responses, nil
+}
+
+// nextID returns a request ID that is unique for the connection.
+func (h *HttpConn) nextID() int64 {
+	return h.id.Add(1)
+}
+
+// do posts reqBytes with retries and failover. check validates the JSON body of a
+// successful HTTP response.
+func (h *HttpConn) do(ctx context.Context, methods []string, reqBytes []byte, write bool, check func([]byte) error) ([]byte, error) {
+	if len(h.endpoints) == 0 {
+		return []byte{}, errors.New("no rpc endpoint configured")
+	}
+
+	var previous *endpoint
+	for attempt := 1; ; attempt++ {
+		e := h.pickEndpoint(previous)
+		info := &RequestInfo{Methods: methods, Endpoint: e.url, Attempt: attempt}
+		start := time.Now()
+		statusCode, bodyBytes, err := h.send(ctx, info, reqBytes, write)
+		if err == nil {
+			err = check(bodyBytes)
+		}
+		h.intercepted(ctx, info, &ResponseInfo{StatusCode: statusCode, Body: bodyBytes, Err: err, Duration: time.Since(start)})
+		var rejected *interceptorError
+		if errors.As(err, &rejected) {
+			return []byte{}, rejected.err
+		}
+		if err == nil {
+			h.markHealthy(e)
+			return bodyBytes, nil
+		}
+		if ctx.Err() != nil {
+			return []byte{}, err
+		}
+		retryable, unhealthy := classify(err, write)
+		if unhealthy {
+			h.markUnhealthy(e)
+		} else {
+			h.markHealthy(e)
+		}
+		if !retryable || attempt >= h.retry.MaxAttempts {
+			return []byte{}, err
+		}
+		previous = e
+
+		timer := time.NewTimer(h.retry.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return []byte{}, err
+		case <-timer.C:
+		}
+	}
+}
+
+func (h *HttpConn) send(ctx context.Context, info *RequestInfo, reqBytes []byte, write bool) (int, []byte, error) {
+	if h.rl != nil {
+		if err := h.rl.Wait(ctx); err != nil {
+			return 0, []byte{}, err
+		}
+	}
+	if h.timeout > 0 && !write {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "POST", info.Endpoint, bytes.NewReader(reqBytes))
+	if err != nil {
+		return 0, []byte{}, err
 	}
-	request = request.WithContext(ctx)
 	request.Header.Add("Content-Type", "application/json")
-	rsp, err := h.c.Do(request.WithContext(ctx))
+	if err := h.prepare(ctx, info, request); err != nil {
+		return 0, []byte{}, &interceptorError{err: err}
+	}
+	rsp, err := h.c.Do(request)
 	if err != nil {
-		return []byte{}, err
+		return 0, []byte{}, err
 	}
 	defer rsp.Body.Close()
 
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	if err != nil {
-		return []byte{}, err
+		return rsp.StatusCode, []byte{}, err
+	}
+	if err := checkResponse(rsp.StatusCode, rsp.Status, bodyBytes); err != nil {
+		return rsp.StatusCode, []byte{}, err
+	}
+	return rsp.StatusCode, bodyBytes, nil
+}
+
+// pickEndpoint returns the first healthy endpoint, preferring one other than the
+// endpoint that just failed. If no endpoint is healthy, it returns the one that
+// recovers first.
+func (h *HttpConn) pickEndpoint(previous *endpoint) *endpoint {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	var fallback, recovering *endpoint
+	for _, e := range h.endpoints {
+		if now.Before(e.unhealthyUntil) {
+			if recovering == nil || e.unhealthyUntil.Before(recovering.unhealthyUntil) {
+				recovering = e
+			}
+			continue
+		}
+		if e != previous {
+			return e
+		}
+		fallback = e
+	}
+	if fallback != nil {
+		return fallback
+	}
+	return recovering
+}
+
+func (h *HttpConn) markHealthy(e *endpoint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e.failures = 0
+	e.unhealthyUntil = time.Time{}
+}
+
+func (h *HttpConn) markUnhealthy(e *endpoint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e.failures++
+	cooldown := h.cooldown
+	for i := 1; i < e.failures && cooldown < maxHealthCooldown; i++ {
+		cooldown *= 2
+	}
+	if cooldown > maxHealthCooldown {
+		cooldown = maxHealthCooldown
+	}
+	e.unhealthyUntil = time.Now().Add(cooldown)
+}
+
+// classify reports whether a failed attempt may be retried and whether it says the
+// endpoint is unhealthy.
+func classify(err error, write bool) (retryable bool, unhealthy bool) {
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return !write && rpcErr.Code == CodeTransientError, false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch {
+		case httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode == http.StatusServiceUnavailable:
+			return true, true
+		case httpErr.StatusCode >= 500:
+			return !write, true
+		}
+		return false, false
+	}
+
+	if errors.Is(err, ErrInvalidResponse) {
+		return !write, true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true, true
 	}
-	return bodyBytes, nil
+	return !write, true
 }
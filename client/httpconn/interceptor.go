@@ -0,0 +1,110 @@
+package httpconn
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes one attempt of a JSON-RPC request.
+type RequestInfo struct {
+	// Methods are the JSON-RPC methods of the request, several for a batch.
+	Methods  []string
+	Endpoint string
+	Attempt  int
+	// Request is the HTTP request of the attempt. It is nil in response interceptors
+	// when the request could not be created.
+	Request *http.Request
+}
+
+// ResponseInfo describes the outcome of one attempt of a JSON-RPC request.
+type ResponseInfo struct {
+	// StatusCode is zero if no HTTP response was received.
+	StatusCode int
+	Body       []byte
+	Err        error
+	Duration   time.Duration
+}
+
+// RequestInterceptor is called before every attempt of a request, after the headers of
+// the connection are set. It may change the HTTP request, e.g. to sign it. An error
+// aborts the request without retries.
+type RequestInterceptor func(ctx context.Context, info *RequestInfo) error
+
+// ResponseInterceptor is called after every attempt of a request, e.g. for logging or
+// metrics.
+type ResponseInterceptor func(ctx context.Context, info *RequestInfo, rsp *ResponseInfo)
+
+type interceptorError struct {
+	err error
+}
+
+func (e *interceptorError) Error() string {
+	return e.err.Error()
+}
+
+func (e *interceptorError) Unwrap() error {
+	return e.err
+}
+
+// SetHeader sets headers sent with every request, e.g. the API key of a hosted RPC
+// provider.
+func (h *HttpConn) SetHeader(header http.Header) *HttpConn {
+	h.header = header.Clone()
+	return h
+}
+
+// SetHeaderFunc sets a function returning headers for a request, evaluated before every
+// attempt with the context of the request. They override the headers set by SetHeader.
+func (h *HttpConn) SetHeaderFunc(headerFunc func(ctx context.Context) http.Header) *HttpConn {
+	h.headerFunc = headerFunc
+	return h
+}
+
+// SetUserAgent sets the User-Agent header of requests.
+func (h *HttpConn) SetUserAgent(userAgent string) *HttpConn {
+	h.userAgent = userAgent
+	return h
+}
+
+// AddRequestInterceptor adds an interceptor called before every attempt of a request.
+// Interceptors are called in the order they were added.
+func (h *HttpConn) AddRequestInterceptor(interceptor RequestInterceptor) *HttpConn {
+	h.requestInterceptors = append(h.requestInterceptors, interceptor)
+	return h
+}
+
+// AddResponseInterceptor adds an interceptor called after every attempt of a request.
+// Interceptors are called in the order they were added.
+func (h *HttpConn) AddResponseInterceptor(interceptor ResponseInterceptor) *HttpConn {
+	h.responseInterceptors = append(h.responseInterceptors, interceptor)
+	return h
+}
+
+func (h *HttpConn) prepare(ctx context.Context, info *RequestInfo, request *http.Request) error {
+	for key, values := range h.header {
+		request.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+	if h.headerFunc != nil {
+		for key, values := range h.headerFunc(ctx) {
+			request.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+		}
+	}
+	if h.userAgent != "" {
+		request.Header.Set("User-Agent", h.userAgent)
+	}
+
+	info.Request = request
+	for _, interceptor := range h.requestInterceptors {
+		if err := interceptor(ctx, info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *HttpConn) intercepted(ctx context.Context, info *RequestInfo, rsp *ResponseInfo) {
+	for _, interceptor := range h.responseInterceptors {
+		interceptor(ctx, info, rsp)
+	}
+}
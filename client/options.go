@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
+	"github.com/mangonet-labs/mgo-go-sdk/client/wsconn"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/time/rate"
+)
+
+const defaultHeartbeat = 30 * time.Second
+
+// Option configures a Client or a WebsocketClient. Options that have no meaning for
+// websockets, such as rate limits, retries and interceptors, only apply to the HTTP client.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	httpClient           *http.Client
+	endpoints            []string
+	header               http.Header
+	headerFunc           func(ctx context.Context) http.Header
+	userAgent            string
+	timeout              *time.Duration
+	rateLimiter          *rate.Limiter
+	rateLimiterSet       bool
+	retryPolicy          *httpconn.RetryPolicy
+	requestInterceptors  []httpconn.RequestInterceptor
+	responseInterceptors []httpconn.ResponseInterceptor
+	heartbeat            time.Duration
+	reconnectPolicy      *httpconn.RetryPolicy
+}
+
+// WithHTTPClient sets the http.Client used for requests. For websockets, the proxy and
+// TLS configuration of its *http.Transport and its cookie jar are used.
+func WithHTTPClient(cli *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = cli
+	}
+}
+
+// WithFailoverEndpoints adds RPC URLs the HTTP client fails over to.
+func WithFailoverEndpoints(rpcUrls ...string) Option {
+	return func(o *clientOptions) {
+		o.endpoints = append(o.endpoints, rpcUrls...)
+	}
+}
+
+// WithHeader adds a header sent with every request, e.g. an API key.
+func WithHeader(key, value string) Option {
+	return func(o *clientOptions) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// WithHeaderFunc sets a function returning headers for each request, e.g. short lived
+// tokens. Its headers override the ones set by WithHeader. For websockets, it is called
+// once when connecting.
+func WithHeaderFunc(headerFunc func(ctx context.Context) http.Header) Option {
+	return func(o *clientOptions) {
+		o.headerFunc = headerFunc
+	}
+}
+
+// WithUserAgent sets the User-Agent header of requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithTimeout sets the timeout of a single HTTP request attempt, except for writes, and
+// the handshake timeout of websockets. See httpconn.HttpConn.SetTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = &timeout
+	}
+}
+
+// WithRateLimit limits HTTP requests to r per second with bursts of burst requests.
+func WithRateLimit(r rate.Limit, burst int) Option {
+	return WithRateLimiter(rate.NewLimiter(r, burst))
+}
+
+// WithRateLimiter sets the limiter of HTTP requests. Nil disables rate limiting.
+func WithRateLimiter(rl *rate.Limiter) Option {
+	return func(o *clientOptions) {
+		o.rateLimiter = rl
+		o.rateLimiterSet = true
+	}
+}
+
+// WithRetryPolicy sets the retry policy of HTTP requests.
+func WithRetryPolicy(policy httpconn.RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = &policy
+	}
+}
+
+// WithRequestInterceptor adds a hook called before every HTTP request attempt.
+func WithRequestInterceptor(interceptor httpconn.RequestInterceptor) Option {
+	return func(o *clientOptions) {
+		o.requestInterceptors = append(o.requestInterceptors, interceptor)
+	}
+}
+
+// WithResponseInterceptor adds a hook called after every HTTP request attempt.
+func WithResponseInterceptor(interceptor httpconn.ResponseInterceptor) Option {
+	return func(o *clientOptions) {
+		o.responseInterceptors = append(o.responseInterceptors, interceptor)
+	}
+}
+
+// WithHeartbeat sets the interval of websocket pings.
+func WithHeartbeat(d time.Duration) Option {
+	return func(o *clientOptions) {
+		o.heartbeat = d
+	}
+}
+
+// WithReconnectPolicy sets how websocket subscriptions reconnect when their connection
+// breaks, see wsconn.WsConn.SetReconnectPolicy.
+func WithReconnectPolicy(policy httpconn.RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.reconnectPolicy = &policy
+	}
+}
+
+func newClientOptions(opts []Option) *clientOptions {
+	o := &clientOptions{heartbeat: defaultHeartbeat}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// NewMgoClientWithOptions instantiates a new Client for the RPC URL configured by opts.
+func NewMgoClientWithOptions(rpcUrl string, opts ...Option) *Client {
+	o := newClientOptions(opts)
+
+	conn := httpconn.NewMultiEndpointHttpConn(append([]string{rpcUrl}, o.endpoints...), o.httpClient)
+	if o.header != nil {
+		conn.SetHeader(o.header)
+	}
+	if o.headerFunc != nil {
+		conn.SetHeaderFunc(o.headerFunc)
+	}
+	if o.userAgent != "" {
+		conn.SetUserAgent(o.userAgent)
+	}
+	if o.timeout != nil {
+		conn.SetTimeout(*o.timeout)
+	}
+	if o.rateLimiterSet {
+		conn.SetRateLimiter(o.rateLimiter)
+	}
+	if o.retryPolicy != nil {
+		conn.SetRetryPolicy(*o.retryPolicy)
+	}
+	for _, interceptor := range o.requestInterceptors {
+		conn.AddRequestInterceptor(interceptor)
+	}
+	for _, interceptor := range o.responseInterceptors {
+		conn.AddResponseInterceptor(interceptor)
+	}
+	return newClient(conn)
+}
+
+// NewMgoWebsocketClientWithOptions connects to the websocket URL configured by opts and
+// instantiates the WebsocketClient. Unlike NewMgoWebsocketClient, it returns an error
+// if the connection fails.
+func NewMgoWebsocketClientWithOptions(ctx context.Context, rpcUrl string, opts ...Option) (IMgoWebsocketAPI, error) {
+	o := newClientOptions(opts)
+
+	dialer := *websocket.DefaultDialer
+	if o.httpClient != nil {
+		if transport, ok := o.httpClient.Transport.(*http.Transport); ok {
+			dialer.Proxy = transport.Proxy
+			dialer.TLSClientConfig = transport.TLSClientConfig
+		}
+		dialer.Jar = o.httpClient.Jar
+	}
+	if o.timeout != nil {
+		dialer.HandshakeTimeout = *o.timeout
+	}
+
+	header := o.header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if o.headerFunc != nil {
+		for key, values := range o.headerFunc(ctx) {
+			header[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+	if o.userAgent != "" {
+		header.Set("User-Agent", o.userAgent)
+	}
+
+	conn, err := wsconn.DialWsConn(ctx, rpcUrl, &dialer, header, o.heartbeat)
+	if err != nil {
+		return nil, err
+	}
+	if o.reconnectPolicy != nil {
+		conn.SetReconnectPolicy(*o.reconnectPolicy)
+	}
+	return newWebsocketClient(conn), nil
+}
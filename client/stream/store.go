@@ -0,0 +1,112 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CheckpointStore persists the cursor of a stream under a key, so a restarted stream
+// resumes after the last item it delivered.
+type CheckpointStore interface {
+	// Load returns the cursor saved under key, and false if there is none.
+	Load(ctx context.Context, key string) (cursor string, ok bool, err error)
+	// Save saves the cursor under key.
+	Save(ctx context.Context, key string, cursor string) error
+}
+
+// MemoryStore is a CheckpointStore that keeps cursors in memory.
+type MemoryStore struct {
+	mu      sync.Mutex
+	cursors map[string]string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{cursors: map[string]string{}}
+}
+
+func (s *MemoryStore) Load(ctx context.Context, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cursor, ok := s.cursors[key]
+	return cursor, ok, nil
+}
+
+func (s *MemoryStore) Save(ctx context.Context, key string, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursors[key] = cursor
+	return nil
+}
+
+// FileStore is a CheckpointStore that keeps cursors in a JSON file, replaced atomically
+// on every save.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Load(ctx context.Context, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cursors, err := s.read()
+	if err != nil {
+		return "", false, err
+	}
+	cursor, ok := cursors[key]
+	return cursor, ok, nil
+}
+
+func (s *FileStore) Save(ctx context.Context, key string, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cursors, err := s.read()
+	if err != nil {
+		return err
+	}
+	cursors[key] = cursor
+
+	data, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *FileStore) read() (map[string]string, error) {
+	cursors := map[string]string{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cursors, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &cursors); err != nil {
+		return nil, err
+	}
+	return cursors, nil
+}
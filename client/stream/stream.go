@@ -0,0 +1,232 @@
+// Package stream delivers events and transactions by polling the query methods of a
+// full node, for nodes that do not serve websockets.
+//
+// A stream pages through the query results in ascending order and saves the cursor of
+// the last handled item in a CheckpointStore after every page. A restarted stream with
+// the same key and store resumes after that item, so every item is handled at least
+// once: only the items of a page interrupted by a crash are delivered again.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/model/response"
+)
+
+const (
+	defaultPageSize     = 50
+	defaultPollInterval = 2 * time.Second
+)
+
+// Config configures a stream.
+type Config struct {
+	// Key identifies the stream in the Store.
+	Key string
+	// Store persists the cursor of the stream. Nil keeps it in memory.
+	Store CheckpointStore
+	// PageSize is the number of items fetched per request, 50 by default.
+	PageSize uint64
+	// PollInterval is the wait between requests once the stream caught up, and after
+	// failed requests, 2 seconds by default.
+	PollInterval time.Duration
+	// OnError is called with the errors of failed requests, which are retried.
+	OnError func(err error)
+}
+
+func (c *Config) setDefaults() {
+	if c.Store == nil {
+		c.Store = NewMemoryStore()
+	}
+	if c.PageSize == 0 {
+		c.PageSize = defaultPageSize
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaultPollInterval
+	}
+}
+
+// Events calls handler for every event matching req.MgoEventFilter, oldest first, until
+// ctx is done or handler returns an error. Without a saved cursor, it starts after
+// req.Cursor, an optional response.EventId. It returns the error of handler or ctx.
+func Events(ctx context.Context, cli *client.Client, req request.MgoXQueryEventsRequest, cfg Config, handler func(ctx context.Context, event response.MgoEventResponse) error) error {
+	return run(ctx, cfg, req.Cursor, func(ctx context.Context, cursor interface{}, limit uint64) ([]response.MgoEventResponse, bool, error) {
+		req.Cursor = cursor
+		req.Limit = limit
+		req.DescendingOrder = false
+		page, err := cli.MgoXQueryEvents(ctx, req)
+		return page.Data, page.HasNextPage, err
+	}, func(event response.MgoEventResponse) (string, error) {
+		b, err := json.Marshal(event.Id)
+		return string(b), err
+	}, func(cursor string) (interface{}, error) {
+		var id response.EventId
+		err := json.Unmarshal([]byte(cursor), &id)
+		return id, err
+	}, handler)
+}
+
+// Transactions calls handler for every transaction matching the query of req, oldest
+// first, until ctx is done or handler returns an error. Without a saved cursor, it
+// starts after req.Cursor, an optional transaction digest. It returns the error of
+// handler or ctx.
+func Transactions(ctx context.Context, cli *client.Client, req request.MgoXQueryTransactionBlocksRequest, cfg Config, handler func(ctx context.Context, txn response.MgoTransactionBlockResponse) error) error {
+	return run(ctx, cfg, req.Cursor, func(ctx context.Context, cursor interface{}, limit uint64) ([]response.MgoTransactionBlockResponse, bool, error) {
+		req.Cursor = cursor
+		req.Limit = limit
+		req.DescendingOrder = false
+		page, err := cli.MgoXQueryTransactionBlocks(ctx, req)
+		return page.Data, page.HasNextPage, err
+	}, func(txn response.MgoTransactionBlockResponse) (string, error) {
+		return txn.Digest, nil
+	}, func(cursor string) (interface{}, error) {
+		return cursor, nil
+	}, handler)
+}
+
+// Message is an item received from the channel of a stream. The stream waits for Ack
+// before it sends the next item, and only saves the cursor of acknowledged items.
+type Message[T any] struct {
+	Item T
+
+	ack  chan struct{}
+	once sync.Once
+}
+
+// Ack marks the item as handled. Call it once the item was processed; an item that is
+// never acknowledged is delivered again when the stream restarts.
+func (m *Message[T]) Ack() {
+	m.once.Do(func() { close(m.ack) })
+}
+
+// EventsChan streams events like Events on the returned channel. Every message must be
+// acknowledged with Ack before the next one is sent. The cursor of the acknowledged
+// events is saved after every page and when the stream stops. The error channel
+// receives the error that stopped the stream; both channels are closed when it stops.
+func EventsChan(ctx context.Context, cli *client.Client, req request.MgoXQueryEventsRequest, cfg Config) (<-chan *Message[response.MgoEventResponse], <-chan error) {
+	return toChan(ctx, func(handler func(ctx context.Context, event response.MgoEventResponse) error) error {
+		return Events(ctx, cli, req, cfg, handler)
+	})
+}
+
+// TransactionsChan streams transactions like Transactions on the returned channel. Every
+// message must be acknowledged with Ack before the next one is sent. The cursor of the
+// acknowledged transactions is saved after every page and when the stream stops. The
+// error channel receives the error that stopped the stream; both channels are closed
+// when it stops.
+func TransactionsChan(ctx context.Context, cli *client.Client, req request.MgoXQueryTransactionBlocksRequest, cfg Config) (<-chan *Message[response.MgoTransactionBlockResponse], <-chan error) {
+	return toChan(ctx, func(handler func(ctx context.Context, txn response.MgoTransactionBlockResponse) error) error {
+		return Transactions(ctx, cli, req, cfg, handler)
+	})
+}
+
+// toChan runs stream with a handler that sends every item on a channel and returns once
+// it is acknowledged, so the stream only saves the cursor of acknowledged items.
+func toChan[T any](ctx context.Context, stream func(handler func(ctx context.Context, item T) error) error) (<-chan *Message[T], <-chan error) {
+	items := make(chan *Message[T])
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(items)
+		errs <- stream(func(ctx context.Context, item T) error {
+			message := &Message[T]{Item: item, ack: make(chan struct{})}
+			select {
+			case items <- message:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			select {
+			case <-message.ack:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return items, errs
+}
+
+type fetchFunc[T any] func(ctx context.Context, cursor interface{}, limit uint64) ([]T, bool, error)
+
+func run[T any](
+	ctx context.Context,
+	cfg Config,
+	start interface{},
+	fetch fetchFunc[T],
+	encode func(item T) (string, error),
+	decode func(cursor string) (interface{}, error),
+	handler func(ctx context.Context, item T) error,
+) error {
+	cfg.setDefaults()
+
+	cursor := start
+	saved, ok, err := cfg.Store.Load(ctx, cfg.Key)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if cursor, err = decode(saved); err != nil {
+			return err
+		}
+	}
+
+	for {
+		items, hasNextPage, err := fetch(ctx, cursor, cfg.PageSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if cfg.OnError != nil {
+				cfg.OnError(err)
+			}
+			if err := wait(ctx, cfg.PollInterval); err != nil {
+				return err
+			}
+			continue
+		}
+
+		last := ""
+		for _, item := range items {
+			if err := handler(ctx, item); err != nil {
+				if last != "" {
+					if saveErr := cfg.Store.Save(ctx, cfg.Key, last); saveErr != nil {
+						return saveErr
+					}
+				}
+				return err
+			}
+			if last, err = encode(item); err != nil {
+				return err
+			}
+		}
+		if last != "" {
+			if err := cfg.Store.Save(ctx, cfg.Key, last); err != nil {
+				return err
+			}
+			if cursor, err = decode(last); err != nil {
+				return err
+			}
+		}
+
+		if !hasNextPage || len(items) == 0 {
+			if err := wait(ctx, cfg.PollInterval); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
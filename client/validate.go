@@ -47,6 +47,15 @@ func (v *defaultValidator) handleErr(err error) error {
 	return err
 }
 
+// ValidateFilter validates the filters that can validate themselves, such as those of
+// package model/filter.
+func (v *defaultValidator) ValidateFilter(filter interface{}) error {
+	if f, ok := filter.(interface{ Validate() error }); ok {
+		return f.Validate()
+	}
+	return nil
+}
+
 func kindOfData(data interface{}) reflect.Kind {
 	value := reflect.ValueOf(data)
 	valueType := value.Kind()
@@ -1,11 +1,17 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/mangonet-labs/mgo-go-sdk/client/wsconn"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/model/response"
 )
 
+var errNoSubscriptions = errors.New("websocket client does not support subscription handles")
+
 // IMgoWebsocketAPI defines the subscription API related interface, and then implement it by the WebsocketClient.
 type IMgoWebsocketAPI interface {
 	ISubscribeAPI
@@ -19,19 +25,37 @@ type WebsocketClient struct {
 // NewMgoWebsocketClient instantiates the WebsocketClient to call the methods of each module.
 func NewMgoWebsocketClient(rpcUrl string) IMgoWebsocketAPI {
 	conn := wsconn.NewWsConn(rpcUrl)
-	return &WebsocketClient{
-		ISubscribeAPI: &mgoSubscribeImpl{
-			conn: conn,
-		},
-	}
+	return newWebsocketClient(conn)
 }
 
 // NewMgoWebsocketClientWithDuration instantiates the WebsocketClient to call the methods of each module, parameter d is for sending heartbeat kit
 func NewMgoWebsocketClientWithDuration(rpcUrl string, d time.Duration) IMgoWebsocketAPI {
 	conn := wsconn.NewWsConnWithDuration(rpcUrl, d)
+	return newWebsocketClient(conn)
+}
+
+func newWebsocketClient(conn *wsconn.WsConn) *WebsocketClient {
 	return &WebsocketClient{
 		ISubscribeAPI: &mgoSubscribeImpl{
 			conn: conn,
 		},
 	}
 }
+
+// NewEventSubscription implements ISubscriptionAPI, see mgoSubscribeImpl.NewEventSubscription.
+func (c *WebsocketClient) NewEventSubscription(ctx context.Context, req request.MgoSubscribeEventsRequest, msgCh chan response.MgoEventResponse) (*Subscription, error) {
+	subscriptions, ok := c.ISubscribeAPI.(ISubscriptionAPI)
+	if !ok {
+		return nil, errNoSubscriptions
+	}
+	return subscriptions.NewEventSubscription(ctx, req, msgCh)
+}
+
+// NewTransactionSubscription implements ISubscriptionAPI, see mgoSubscribeImpl.NewTransactionSubscription.
+func (c *WebsocketClient) NewTransactionSubscription(ctx context.Context, req request.MgoSubscribeTransactionsRequest, msgCh chan response.MgoEffects) (*Subscription, error) {
+	subscriptions, ok := c.ISubscribeAPI.(ISubscriptionAPI)
+	if !ok {
+		return nil, errNoSubscriptions
+	}
+	return subscriptions.NewTransactionSubscription(ctx, req, msgCh)
+}
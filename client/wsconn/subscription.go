@@ -1,7 +1,202 @@
 package wsconn
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+const queueSize = 128
+
 type SubscriptionResp struct {
 	Jsonrpc string `json:"jsonrpc" yaml:"jsonrpc"`
 	Result  int64  `json:"result"  yaml:"result"`
 	Id      int64  `json:"id"      yaml:"id"`
 }
+
+// Handler is called with the result of every notification of a subscription. It should
+// return when ctx is done. An error is reported on the Errors channel of the
+// subscription, which keeps running.
+type Handler func(ctx context.Context, result json.RawMessage) error
+
+// Subscription is a subscription that survives broken connections: the connection is
+// redialed with backoff and the subscription is renewed, which changes its ID.
+//
+// Notifications are queued per subscription and passed to its handler by its own
+// goroutine, so a slow handler does not hold up other subscriptions or calls on the
+// connection. When the queue of a subscription is full, new notifications are dropped
+// and ErrNotificationDropped is reported on its Errors channel.
+type Subscription struct {
+	w                 *WsConn
+	op                CallOp
+	unsubscribeMethod string
+	handler           Handler
+
+	id     atomic.Int64 // zero while not subscribed
+	ctx    context.Context
+	cancel context.CancelFunc
+	queue  chan json.RawMessage
+	errCh  chan error
+	done   chan struct{}
+
+	stopOnce sync.Once
+	err      error
+}
+
+// Subscribe subscribes with op and calls handler for every notification until ctx is
+// done or Unsubscribe is called. unsubscribeMethod, e.g. `mgox_unsubscribeEvent`, is
+// called when the subscription ends. It returns an error if the first subscription
+// fails; later failures are reported on the Errors channel.
+func (w *WsConn) Subscribe(ctx context.Context, op CallOp, unsubscribeMethod string, handler Handler) (*Subscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Subscription{
+		w:                 w,
+		op:                op,
+		unsubscribeMethod: unsubscribeMethod,
+		handler:           handler,
+		ctx:               ctx,
+		cancel:            cancel,
+		queue:             make(chan json.RawMessage, queueSize),
+		errCh:             make(chan error, 16),
+		done:              make(chan struct{}),
+	}
+
+	w.mu.Lock()
+	w.subs[s] = struct{}{}
+	w.mu.Unlock()
+	if err := s.subscribe(ctx); err != nil {
+		w.mu.Lock()
+		delete(w.subs, s)
+		w.mu.Unlock()
+		cancel()
+		return nil, err
+	}
+
+	go s.run()
+	return s, nil
+}
+
+// subscribe subscribes on the current connection and routes its notifications to s.
+func (s *Subscription) subscribe(ctx context.Context) error {
+	_, err := s.w.call(ctx, s.op, func(result gjson.Result) {
+		s.w.mu.Lock()
+		defer s.w.mu.Unlock()
+		if _, active := s.w.subs[s]; !active {
+			return
+		}
+		s.id.Store(result.Int())
+		s.w.routes[result.Int()] = s
+	})
+	return err
+}
+
+// ID returns the current subscription ID assigned by the node, or zero while the
+// subscription is being renewed.
+func (s *Subscription) ID() int64 {
+	return s.id.Load()
+}
+
+// Errors returns a channel of the errors the subscription recovered from, such as
+// broken connections and failing handlers. Errors are dropped when it is full.
+func (s *Subscription) Errors() <-chan error {
+	return s.errCh
+}
+
+// Done returns a channel that is closed when the subscription has stopped.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error that stopped the subscription, or nil if it was stopped by its
+// context or by Unsubscribe.
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Unsubscribe ends the subscription on the node and stops it.
+func (s *Subscription) Unsubscribe(ctx context.Context) error {
+	id := s.detach()
+	var err error
+	if s.unsubscribeMethod != "" && id != 0 {
+		_, err = s.w.call(ctx, CallOp{Method: s.unsubscribeMethod, Params: []interface{}{id}}, nil)
+	}
+	s.stop(nil)
+
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return err
+}
+
+// deliver queues a notification without blocking the reader of the connection.
+func (s *Subscription) deliver(result json.RawMessage) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	select {
+	case s.queue <- result:
+	default:
+		s.report(fmt.Errorf("%w: subscription %d", ErrNotificationDropped, s.ID()))
+	}
+}
+
+func (s *Subscription) report(err error) {
+	select {
+	case s.errCh <- err:
+	default:
+	}
+}
+
+// detach stops routing notifications to s and returns its subscription ID.
+func (s *Subscription) detach() int64 {
+	s.w.mu.Lock()
+	defer s.w.mu.Unlock()
+	delete(s.w.subs, s)
+	id := s.id.Swap(0)
+	if s.w.routes[id] == s {
+		delete(s.w.routes, id)
+	}
+	return id
+}
+
+func (s *Subscription) stop(err error) {
+	s.stopOnce.Do(func() {
+		s.err = err
+		s.cancel()
+	})
+}
+
+func (s *Subscription) run() {
+	defer close(s.done)
+	for {
+		select {
+		case <-s.ctx.Done():
+			// The subscription stopped without Unsubscribe, end it on the node in the
+			// background.
+			if id := s.detach(); id != 0 && s.unsubscribeMethod != "" {
+				go func() {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+					_, _ = s.w.call(ctx, CallOp{Method: s.unsubscribeMethod, Params: []interface{}{id}}, nil)
+				}()
+			}
+			return
+		case result := <-s.queue:
+			if err := s.handler(s.ctx, result); err != nil {
+				s.report(err)
+			}
+		}
+	}
+}
@@ -3,20 +3,60 @@ package wsconn
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
 	"github.com/mangonet-labs/mgo-go-sdk/model/request"
 
 	"github.com/gorilla/websocket"
 	"github.com/tidwall/gjson"
 )
 
+const defaultHeartbeat = 30 * time.Second
+
+var (
+	ErrClosed       = errors.New("websocket connection closed")
+	ErrDisconnected = errors.New("websocket disconnected")
+	// ErrNotificationDropped is reported on the Errors channel of a subscription whose
+	// queue was full when a notification arrived.
+	ErrNotificationDropped = errors.New("subscription queue full, notification dropped")
+)
+
+// DefaultReconnectPolicy is the reconnect policy of a new WsConn. It retries forever.
+var DefaultReconnectPolicy = httpconn.RetryPolicy{
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// WsConn multiplexes calls and subscriptions over a single websocket connection. The
+// connection is dialed on first use. A single reader matches responses to calls by
+// request ID and routes notifications to subscriptions by subscription ID; writes are
+// serialized. When the connection breaks, it is redialed with backoff and the active
+// subscriptions are renewed.
 type WsConn struct {
-	Conn   *websocket.Conn
-	wsUrl  string
-	ticker *time.Ticker // For heartbeat, default 30s
+	wsUrl     string
+	dialer    *websocket.Dialer
+	header    http.Header
+	heartbeat time.Duration // For heartbeat, default 30s
+	reconnect httpconn.RetryPolicy
+	id        atomic.Int64
+
+	dialMu       sync.Mutex
+	writeMu      sync.Mutex
+	mu           sync.Mutex
+	conn         *websocket.Conn
+	pending      map[int64]*pendingCall
+	subs         map[*Subscription]struct{}
+	routes       map[int64]*Subscription
+	reconnecting bool
+	closed       bool
 }
 
 type CallOp struct {
@@ -24,122 +64,345 @@ type CallOp struct {
 	Params []interface{}
 }
 
-// NewWsConn returns a new WsConn given a websocket url. It dials the url and
-// returns a new WsConn. The WsConn has a ticker that sends a ping every 30
-// seconds to keep the connection alive.
+type pendingCall struct {
+	ch chan callResult
+	// onResult is called by the reader with a successful result, before any later
+	// message is read.
+	onResult func(result gjson.Result)
+}
+
+type callResult struct {
+	message []byte
+	err     error
+}
+
+// NewWsConn returns a new WsConn given a websocket url. The connection is dialed on
+// first use, and pinged every 30 seconds to keep it alive.
 func NewWsConn(wsUrl string) *WsConn {
-	dialer := websocket.Dialer{}
-	conn, _, err := dialer.Dial(wsUrl, nil)
+	return NewWsConnWithDuration(wsUrl, defaultHeartbeat)
+}
 
-	if err != nil {
-		log.Fatal("Error connecting to Websocket Server:", err, wsUrl)
+// NewWsConnWithDuration returns a new WsConn given a websocket url and a custom duration.
+// The connection is dialed on first use, and pinged at the specified duration to keep
+// it alive.
+func NewWsConnWithDuration(wsUrl string, d time.Duration) *WsConn {
+	return &WsConn{
+		wsUrl:     wsUrl,
+		dialer:    websocket.DefaultDialer,
+		heartbeat: d,
+		reconnect: DefaultReconnectPolicy,
+		pending:   map[int64]*pendingCall{},
+		subs:      map[*Subscription]struct{}{},
+		routes:    map[int64]*Subscription{},
+	}
+}
+
+// DialWsConn returns a new WsConn connected to the websocket url with the dialer and
+// headers, which pings the connection every d to keep it alive. A nil dialer uses
+// websocket.DefaultDialer.
+func DialWsConn(ctx context.Context, wsUrl string, dialer *websocket.Dialer, header http.Header, d time.Duration) (*WsConn, error) {
+	w := NewWsConnWithDuration(wsUrl, d)
+	if dialer != nil {
+		w.dialer = dialer
 	}
+	w.header = header
 
-	return &WsConn{
-		Conn:   conn,
-		wsUrl:  wsUrl,
-		ticker: time.NewTicker(30 * time.Second),
+	if err := w.connect(ctx); err != nil {
+		return nil, err
 	}
+	return w, nil
 }
 
-// NewWsConnWithDuration returns a new WsConn given a websocket url and a custom duration.
-// It dials the url and returns a new WsConn. The WsConn has a ticker that sends a ping
-// at the specified duration to keep the connection alive.
-func NewWsConnWithDuration(wsUrl string, d time.Duration) *WsConn {
-	dialer := websocket.Dialer{}
-	conn, _, err := dialer.Dial(wsUrl, nil)
+// SetReconnectPolicy sets how a broken connection is redialed. A MaxAttempts of zero
+// retries forever; otherwise the subscriptions fail after MaxAttempts consecutive
+// failed reconnects.
+func (w *WsConn) SetReconnectPolicy(policy httpconn.RetryPolicy) *WsConn {
+	w.reconnect = policy
+	return w
+}
 
+// Close stops all subscriptions and closes the connection.
+func (w *WsConn) Close() error {
+	w.mu.Lock()
+	w.closed = true
+	conn := w.conn
+	subs := make([]*Subscription, 0, len(w.subs))
+	for sub := range w.subs {
+		subs = append(subs, sub)
+	}
+	w.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.stop(ErrClosed)
+	}
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
+
+// Call sends a JSON-RPC request and returns the result of its response.
+func (w *WsConn) Call(ctx context.Context, op CallOp) (json.RawMessage, error) {
+	result, err := w.call(ctx, op, nil)
 	if err != nil {
-		log.Fatal("Error connecting to Websocket Server:", err, wsUrl)
+		return nil, err
 	}
+	return json.RawMessage(result.Raw), nil
+}
 
-	return &WsConn{
-		Conn:   conn,
-		wsUrl:  wsUrl,
-		ticker: time.NewTicker(d),
-	}
-}
-
-// Call sends a JSON-RPC request over the websocket connection and listens for responses.
-// It marshals the provided CallOp into a JSON-RPC request, writes it to the websocket,
-// and then reads messages from the websocket connection. If a message contains an error,
-// it returns the error. It starts a goroutine to periodically send ping messages to
-// keep the connection alive. Another goroutine listens for incoming messages and sends
-// them to the provided channel. The function returns an error if there are issues with
-// sending or receiving messages.
-//
-// Parameters:
-// - ctx: The context to control cancellation and timeout.
-// - op: The CallOp containing method and parameters for the RPC call.
-// - receiveMsgCh: A channel to receive messages from the websocket.
-//
-// Returns:
-// - error: An error if the call fails at any point.
-func (w *WsConn) Call(ctx context.Context, op CallOp, receiveMsgCh chan []byte) error {
-	jsonRPCCall := request.JsonRPCRequest{
+func (w *WsConn) call(ctx context.Context, op CallOp, onResult func(result gjson.Result)) (gjson.Result, error) {
+	if err := w.connect(ctx); err != nil {
+		return gjson.Result{}, err
+	}
+
+	id := w.id.Add(1)
+	callBytes, err := json.Marshal(request.JsonRPCRequest{
 		JsonRPC: "2.0",
-		ID:      time.Now().UnixMilli(),
+		ID:      id,
 		Method:  op.Method,
 		Params:  op.Params,
-	}
-
-	callBytes, err := json.Marshal(jsonRPCCall)
+	})
 	if err != nil {
-		return err
+		return gjson.Result{}, err
 	}
 
-	err = w.Conn.WriteMessage(websocket.TextMessage, callBytes)
-	if nil != err {
-		return err
+	call := &pendingCall{ch: make(chan callResult, 1), onResult: onResult}
+	w.mu.Lock()
+	conn := w.conn
+	if conn == nil {
+		w.mu.Unlock()
+		return gjson.Result{}, ErrDisconnected
 	}
+	w.pending[id] = call
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		delete(w.pending, id)
+		w.mu.Unlock()
+	}()
 
-	_, messageData, err := w.Conn.ReadMessage()
-	if nil != err {
-		return err
+	if err := w.write(conn, callBytes); err != nil {
+		return gjson.Result{}, err
+	}
+	select {
+	case result := <-call.ch:
+		if result.err != nil {
+			return gjson.Result{}, result.err
+		}
+		if err := httpconn.ParseRPCError(result.message); err != nil {
+			return gjson.Result{}, err
+		}
+		return gjson.GetBytes(result.message, "result"), nil
+	case <-ctx.Done():
+		return gjson.Result{}, ctx.Err()
 	}
+}
 
-	var rsp SubscriptionResp
-	if gjson.ParseBytes(messageData).Get("error").Exists() {
-		return fmt.Errorf(gjson.ParseBytes(messageData).Get("error").String())
+func (w *WsConn) write(conn *websocket.Conn, message []byte) error {
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+	return conn.WriteMessage(websocket.TextMessage, message)
+}
+
+// connect dials the connection unless it is connected.
+func (w *WsConn) connect(ctx context.Context) error {
+	w.dialMu.Lock()
+	defer w.dialMu.Unlock()
+
+	w.mu.Lock()
+	connected, closed := w.conn != nil, w.closed
+	w.mu.Unlock()
+	if closed {
+		return ErrClosed
+	}
+	if connected {
+		return nil
 	}
 
-	err = json.Unmarshal([]byte(gjson.ParseBytes(messageData).String()), &rsp)
+	conn, _, err := w.dialer.DialContext(ctx, w.wsUrl, w.header)
 	if err != nil {
-		return err
+		return fmt.Errorf("connect to websocket server %s: %w", w.wsUrl, err)
+	}
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		conn.Close()
+		return ErrClosed
 	}
+	w.conn = conn
+	w.mu.Unlock()
 
-	fmt.Printf("establish successfully, subscriptionID: %d, Waiting to accept data...\n", rsp.Result)
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				w.ticker.Stop()
-				return
-			case <-w.ticker.C:
-				if err := w.Conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(10*time.Second)); err != nil {
-					log.Println("ping failed:", err)
-					return
-				}
+	done := make(chan struct{})
+	go w.read(conn, done)
+	go w.ping(conn, done)
+	return nil
+}
+
+// read is the only reader of conn. It dispatches responses and notifications until
+// the connection breaks.
+func (w *WsConn) read(conn *websocket.Conn, done chan struct{}) {
+	defer close(done)
+	for {
+		messageType, messageData, err := conn.ReadMessage()
+		if err != nil {
+			w.disconnected(conn, err)
+			return
+		}
+		if messageType != websocket.TextMessage {
+			continue
+		}
+
+		message := gjson.ParseBytes(messageData)
+		if subscription := message.Get("params.subscription"); subscription.Exists() {
+			w.mu.Lock()
+			sub := w.routes[subscription.Int()]
+			w.mu.Unlock()
+			if sub != nil {
+				sub.deliver(json.RawMessage(message.Get("params.result").Raw))
 			}
+			continue
 		}
-	}()
 
-	go func(conn *websocket.Conn) {
-		for {
-			messageType, messageData, err := conn.ReadMessage()
-			if nil != err {
-				log.Println(err)
-				break
+		id := message.Get("id")
+		if !id.Exists() {
+			continue
+		}
+		w.mu.Lock()
+		call := w.pending[id.Int()]
+		delete(w.pending, id.Int())
+		w.mu.Unlock()
+		if call == nil {
+			continue
+		}
+		if call.onResult != nil && httpconn.ParseRPCError(messageData) == nil {
+			call.onResult(message.Get("result"))
+		}
+		call.ch <- callResult{message: messageData}
+	}
+}
+
+func (w *WsConn) ping(conn *websocket.Conn, done chan struct{}) {
+	if w.heartbeat <= 0 {
+		return
+	}
+	ticker := time.NewTicker(w.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(10*time.Second)); err != nil {
+				conn.Close()
+				return
 			}
-			switch messageType {
-			case websocket.TextMessage:
-				receiveMsgCh <- messageData
+		}
+	}
+}
+
+// disconnected fails the pending calls of the broken connection and starts renewing
+// the active subscriptions.
+func (w *WsConn) disconnected(conn *websocket.Conn, err error) {
+	conn.Close()
+
+	w.mu.Lock()
+	if w.conn == conn {
+		w.conn = nil
+	}
+	for id, call := range w.pending {
+		call.ch <- callResult{err: fmt.Errorf("%w: %v", ErrDisconnected, err)}
+		delete(w.pending, id)
+	}
+	w.routes = map[int64]*Subscription{}
+	subs := make([]*Subscription, 0, len(w.subs))
+	for sub := range w.subs {
+		sub.id.Store(0)
+		subs = append(subs, sub)
+	}
+	start := len(subs) > 0 && !w.closed && !w.reconnecting
+	if start {
+		w.reconnecting = true
+	}
+	w.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.report(fmt.Errorf("%w: %v", ErrDisconnected, err))
+	}
+	if start {
+		go w.resubscribe()
+	}
+}
+
+// resubscribe redials the connection and renews the active subscriptions until all of
+// them are subscribed on a live connection.
+func (w *WsConn) resubscribe() {
+	for attempt := 1; ; attempt++ {
+		time.Sleep(w.reconnect.Backoff(attempt))
 
-			default:
-				continue
+		err := w.renew()
+		w.mu.Lock()
+		if w.closed || len(w.subs) == 0 || (err == nil && w.conn != nil && w.allSubscribed()) {
+			w.reconnecting = false
+			w.mu.Unlock()
+			return
+		}
+		subs := make([]*Subscription, 0, len(w.subs))
+		for sub := range w.subs {
+			subs = append(subs, sub)
+		}
+		w.mu.Unlock()
+
+		if err == nil {
+			// The connection broke again while renewing.
+			attempt = 0
+			continue
+		}
+		giveUp := w.reconnect.MaxAttempts > 0 && attempt >= w.reconnect.MaxAttempts
+		for _, sub := range subs {
+			if giveUp {
+				sub.stop(err)
+			} else {
+				sub.report(err)
 			}
 		}
-	}(w.Conn)
+		if giveUp {
+			w.mu.Lock()
+			w.reconnecting = false
+			w.mu.Unlock()
+			return
+		}
+	}
+}
+
+func (w *WsConn) renew() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := w.connect(ctx); err != nil {
+		return err
+	}
 
+	w.mu.Lock()
+	subs := make([]*Subscription, 0, len(w.subs))
+	for sub := range w.subs {
+		if sub.id.Load() == 0 {
+			subs = append(subs, sub)
+		}
+	}
+	w.mu.Unlock()
+	for _, sub := range subs {
+		if err := sub.subscribe(ctx); err != nil && sub.ctx.Err() == nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// allSubscribed must be called with w.mu held.
+func (w *WsConn) allSubscribed() bool {
+	for sub := range w.subs {
+		if sub.id.Load() == 0 {
+			return false
+		}
+	}
+	return true
+}
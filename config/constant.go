@@ -12,19 +12,25 @@ var (
 	SIGNATURE_FLAG_TO_SCHEME = map[Scheme]string{
 		0x00: "ED25519",
 		0x01: "Secp256k1",
+		0x02: "Secp256r1",
+		0x03: "MultiSig",
 	}
 	SIGNATURE_SCHEME_TO_FLAG = map[string]Scheme{
 		"ED25519":   0x00,
 		"Secp256k1": 0x01,
+		"Secp256r1": 0x02,
+		"MultiSig":  0x03,
 	}
 	SIGNATURE_SCHEME_TO_SIZE = map[string]int{
 		"ED25519":   32,
 		"Secp256k1": 33,
+		"Secp256r1": 33,
 	}
 
 	DERIVATION_PATH = map[Scheme]string{
 		0x00: `m/44'/938'/0'/0'/0'`,
 		0x01: `m/54'/938'/0'/0/0`,
+		0x02: `m/74'/938'/0'/0/0`,
 	}
 )
 
@@ -35,6 +41,8 @@ type Signtype byte
 const (
 	Ed25519Flag   Scheme = 0
 	Secp256k1Flag Scheme = 1
+	Secp256r1Flag Scheme = 2
+	MultiSigFlag  Scheme = 3
 	ErrorFlag     byte   = math.MaxUint8
 )
 
@@ -54,6 +62,7 @@ const (
 const (
 	Ed25519PublicKeyLength   = 32
 	Secp256k1PublicKeyLength = 33
+	Secp256r1PublicKeyLength = 33
 )
 
 const (
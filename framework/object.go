@@ -0,0 +1,122 @@
+// Package framework decodes the BCS bytes of Move objects into Go structs, and provides
+// the layouts of common framework objects.
+//
+//	var coin framework.Coin
+//	data, err := framework.GetObject(ctx, cli, coinId, &coin)
+//	// coin.Balance.Value
+//
+// A Go struct decodes a Move struct when their fields have the same order and BCS
+// layout: u64 is uint64, address and ID are model.MgoAddressBytes, vector<T> is []T and
+// Option<T> is a pointer field tagged `bcs:"optional"`.
+package framework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/model/response"
+	"github.com/mangonet-labs/mgo-go-sdk/typetag"
+)
+
+var (
+	ErrTypeMismatch = errors.New("object type mismatch")
+	ErrNoBcs        = errors.New("object has no move object bcs")
+)
+
+// MoveObject is implemented by the Go layouts of Move objects.
+type MoveObject interface {
+	// MoveType returns the Move type of the objects decoded into the layout, such as
+	// `0x2::coin::Coin`. A type without type arguments matches any type arguments.
+	MoveType() string
+}
+
+// GetObject fetches the object with its BCS bytes and decodes it into v, after checking
+// that the object type matches v.MoveType().
+func GetObject(ctx context.Context, cli *client.Client, objectId string, v MoveObject) (*response.MgoObjectData, error) {
+	return GetObjectAs(ctx, cli, objectId, v.MoveType(), v)
+}
+
+// GetObjectAs fetches the object with its BCS bytes and decodes it into v, a pointer to
+// a struct, after checking that the object type matches moveType.
+func GetObjectAs(ctx context.Context, cli *client.Client, objectId string, moveType string, v any) (*response.MgoObjectData, error) {
+	rsp, err := cli.MgoGetObject(ctx, request.MgoGetObjectRequest{
+		ObjectId: objectId,
+		Options: request.MgoObjectDataOptions{
+			ShowType: true,
+			ShowBcs:  true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Error != nil {
+		return nil, fmt.Errorf("get object %s: %s", objectId, rsp.Error.Code)
+	}
+	if rsp.Data == nil {
+		return nil, fmt.Errorf("get object %s: no data", objectId)
+	}
+	if err := DecodeAs(rsp.Data, moveType, v); err != nil {
+		return nil, err
+	}
+	return rsp.Data, nil
+}
+
+// Decode decodes the BCS bytes of an object fetched with ShowBcs into v, after checking
+// that the object type matches v.MoveType().
+func Decode(data *response.MgoObjectData, v MoveObject) error {
+	return DecodeAs(data, v.MoveType(), v)
+}
+
+// DecodeAs decodes the BCS bytes of an object fetched with ShowBcs into v, a pointer to
+// a struct, after checking that the object type matches moveType.
+func DecodeAs(data *response.MgoObjectData, moveType string, v any) error {
+	if data.Bcs == nil || data.Bcs.DataType != "moveObject" {
+		return fmt.Errorf("%w: %s", ErrNoBcs, data.ObjectId)
+	}
+	objectType := data.Bcs.Type
+	if objectType == "" {
+		objectType = data.Type
+	}
+	if err := MatchType(objectType, moveType); err != nil {
+		return err
+	}
+
+	b, err := bcs.FromBase64(data.Bcs.BcsBytes)
+	if err != nil {
+		return err
+	}
+	n, err := bcs.Unmarshal(b, v)
+	if err != nil {
+		return fmt.Errorf("decode %s: %w", objectType, err)
+	}
+	if n != len(b) {
+		return fmt.Errorf("decode %s: %d trailing bytes", objectType, len(b)-n)
+	}
+	return nil
+}
+
+// MatchType returns an ErrTypeMismatch error unless objectType matches moveType. A
+// moveType without type arguments matches any type arguments of the same struct.
+func MatchType(objectType, moveType string) error {
+	want, err := typetag.ParseStructTag(moveType)
+	if err != nil {
+		return err
+	}
+	got, err := typetag.ParseStructTag(objectType)
+	if err != nil {
+		return err
+	}
+
+	match := got.Address.IsEqual(want.Address) && got.Module == want.Module && got.Name == want.Name
+	if len(want.TypeParams) > 0 {
+		match = got.CanonicalString() == want.CanonicalString()
+	}
+	if !match {
+		return fmt.Errorf("%w: %s is not a %s", ErrTypeMismatch, objectType, moveType)
+	}
+	return nil
+}
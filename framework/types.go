@@ -0,0 +1,68 @@
+package framework
+
+import (
+	"encoding/hex"
+
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+)
+
+// UID is the BCS layout of `0x2::object::UID`, the ID of an object.
+type UID struct {
+	Id model.MgoAddressBytes
+}
+
+// String returns the object ID in its full 32-byte hex form.
+func (u UID) String() string {
+	return "0x" + hex.EncodeToString(u.Id[:])
+}
+
+// Balance is the BCS layout of `0x2::balance::Balance<T>`. The coin type T is not part of
+// the layout; it is in the type of the object holding the balance.
+type Balance struct {
+	Value uint64
+}
+
+// Coin is the BCS layout of `0x2::coin::Coin<T>`. It matches coins of any type; use
+// GetObjectAs to require a coin type, e.g. `0x2::coin::Coin<0x2::mgo::MGO>`.
+type Coin struct {
+	Id      UID
+	Balance Balance
+}
+
+func (Coin) MoveType() string {
+	return "0x2::coin::Coin"
+}
+
+// StakedMgo is the BCS layout of `0x3::staking_pool::StakedMgo`, a stake in the pool of a
+// validator.
+type StakedMgo struct {
+	Id                   UID
+	PoolId               model.MgoAddressBytes
+	StakeActivationEpoch uint64
+	Principal            Balance
+}
+
+func (StakedMgo) MoveType() string {
+	return "0x3::staking_pool::StakedMgo"
+}
+
+// Table is the header of a `0x2::table::Table<K, V>`. Its entries are dynamic fields of
+// the table ID.
+type Table struct {
+	Id   UID
+	Size uint64
+}
+
+func (Table) MoveType() string {
+	return "0x2::table::Table"
+}
+
+// Bag is the header of a `0x2::bag::Bag`. Its entries are dynamic fields of the bag ID.
+type Bag struct {
+	Id   UID
+	Size uint64
+}
+
+func (Bag) MoveType() string {
+	return "0x2::bag::Bag"
+}
@@ -0,0 +1,114 @@
+package filter
+
+// EventFilter is a filter of `mgox_queryEvents` and `mgox_subscribeEvent`. Build it with
+// the leaf filters of this package and combine them with All, Any, And and Or.
+type EventFilter struct {
+	f filter
+}
+
+// Validate returns the error of the first invalid input the filter was built from.
+func (e EventFilter) Validate() error {
+	return e.f.validate()
+}
+
+func (e EventFilter) MarshalJSON() ([]byte, error) {
+	return e.f.marshal()
+}
+
+// Sender matches the events emitted by transactions sent by address.
+func Sender(addr string) EventFilter {
+	sender, err := address("sender", addr)
+	return EventFilter{newFilter("Sender", sender, err)}
+}
+
+// Transaction matches the events emitted by the transaction with the digest.
+func Transaction(txDigest string) EventFilter {
+	return EventFilter{newFilter("Transaction", txDigest, digest(txDigest))}
+}
+
+// Package matches the events emitted by the Move calls of a package.
+func Package(packageId string) EventFilter {
+	pkg, err := address("package", packageId)
+	return EventFilter{newFilter("Package", pkg, err)}
+}
+
+// MoveModule matches the events emitted by the Move calls of a module.
+func MoveModule(packageId, module string) EventFilter {
+	pkg, err := address("package", packageId)
+	return EventFilter{newFilter("MoveModule", map[string]string{
+		"package": pkg,
+		"module":  module,
+	}, err, identifier("module", module))}
+}
+
+// MoveEventType matches the events of a Move struct type, such as
+// `0x2::coin::CoinMinted`, including its type arguments if it has any.
+func MoveEventType(eventType string) EventFilter {
+	t, err := structType(eventType)
+	return EventFilter{newFilter("MoveEventType", t, err)}
+}
+
+// MoveEventModule matches the events whose Move type is defined in a module.
+func MoveEventModule(packageId, module string) EventFilter {
+	pkg, err := address("package", packageId)
+	return EventFilter{newFilter("MoveEventModule", map[string]string{
+		"package": pkg,
+		"module":  module,
+	}, err, identifier("module", module))}
+}
+
+// MoveEventField matches the events whose JSON field at path, such as `/amount`, equals
+// value.
+func MoveEventField(path string, value interface{}) EventFilter {
+	return EventFilter{newFilter("MoveEventField", map[string]interface{}{
+		"path":  path,
+		"value": value,
+	})}
+}
+
+// TimeRange matches the events emitted between startTime, inclusive, and endTime,
+// exclusive, in milliseconds since the Unix epoch.
+func TimeRange(startTime, endTime uint64) EventFilter {
+	var err error
+	if endTime < startTime {
+		err = errorf("time range ends at %d before it starts at %d", endTime, startTime)
+	}
+	return EventFilter{newFilter("TimeRange", map[string]string{
+		"startTime": u64(startTime),
+		"endTime":   u64(endTime),
+	}, err)}
+}
+
+// All matches the events matched by every filter.
+func All(filters ...EventFilter) EventFilter {
+	return EventFilter{newFilter("All", filters, validateAll(filters))}
+}
+
+// Any matches the events matched by at least one filter.
+func Any(filters ...EventFilter) EventFilter {
+	return EventFilter{newFilter("Any", filters, validateAll(filters))}
+}
+
+// And matches the events matched by both filters.
+func And(a, b EventFilter) EventFilter {
+	filters := []EventFilter{a, b}
+	return EventFilter{newFilter("And", filters, validateAll(filters))}
+}
+
+// Or matches the events matched by either filter.
+func Or(a, b EventFilter) EventFilter {
+	filters := []EventFilter{a, b}
+	return EventFilter{newFilter("Or", filters, validateAll(filters))}
+}
+
+func validateAll(filters []EventFilter) error {
+	if len(filters) == 0 {
+		return errorf("no filters to combine")
+	}
+	for _, f := range filters {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Package filter builds the event and transaction filters of the query and subscribe
+// methods, validating addresses, digests and Move types as they are built.
+//
+//	events := filter.Any(
+//		filter.MoveEventType("0x2::coin::CoinMinted"),
+//		filter.And(filter.Sender(owner), filter.MoveModule("0x2", "pay")),
+//	)
+//	rsp, err := cli.MgoXQueryEvents(ctx, request.MgoXQueryEventsRequest{MgoEventFilter: events})
+//
+// A filter built from invalid input keeps the error: it is returned by Validate and
+// when the filter is serialized, so the request fails before it is sent.
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/typetag"
+	"github.com/mangonet-labs/mgo-go-sdk/utils"
+
+	"github.com/mr-tron/base58"
+)
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// filter is a single-key JSON object, such as {"Sender": "0x..."}.
+type filter struct {
+	name  string
+	value interface{}
+	err   error
+}
+
+func newFilter(name string, value interface{}, errs ...error) filter {
+	for _, err := range errs {
+		if err != nil {
+			return filter{name: name, err: err}
+		}
+	}
+	return filter{name: name, value: value}
+}
+
+func (f filter) validate() error {
+	if f.name == "" {
+		return errorf("empty filter")
+	}
+	return f.err
+}
+
+func (f filter) marshal() ([]byte, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]interface{}{f.name: f.value})
+}
+
+func address(field, addr string) (string, error) {
+	if !utils.IsValidMgoAddress(model.MgoAddress(addr)) {
+		return "", errorf("invalid %s address %q", field, addr)
+	}
+	return string(utils.NormalizeMgoAddress(addr)), nil
+}
+
+func identifier(field, name string) error {
+	if !identifierPattern.MatchString(name) {
+		return errorf("invalid %s name %q", field, name)
+	}
+	return nil
+}
+
+func structType(s string) (string, error) {
+	tag, err := typetag.ParseStructTag(s)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+	return tag.String(), nil
+}
+
+func digest(s string) error {
+	b, err := base58.Decode(s)
+	if err != nil || len(b) != 32 {
+		return errorf("invalid transaction digest %q", s)
+	}
+	return nil
+}
+
+func errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidFilter, fmt.Sprintf(format, args...))
+}
+
+func u64(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
@@ -0,0 +1,116 @@
+package filter
+
+// TransactionFilter is a filter of `mgox_queryTransactionBlocks` and
+// `mgox_subscribeTransaction`.
+type TransactionFilter struct {
+	f filter
+}
+
+// Validate returns the error of the first invalid input the filter was built from.
+func (t TransactionFilter) Validate() error {
+	return t.f.validate()
+}
+
+func (t TransactionFilter) MarshalJSON() ([]byte, error) {
+	return t.f.marshal()
+}
+
+// Checkpoint matches the transactions of a checkpoint.
+func Checkpoint(sequenceNumber uint64) TransactionFilter {
+	return TransactionFilter{newFilter("Checkpoint", u64(sequenceNumber))}
+}
+
+// CheckpointRange matches the transactions of the checkpoints from start to end,
+// inclusive.
+func CheckpointRange(start, end uint64) TransactionFilter {
+	var err error
+	if end < start {
+		err = errorf("checkpoint range ends at %d before it starts at %d", end, start)
+	}
+	return TransactionFilter{newFilter("CheckpointRange", map[string]string{
+		"startCheckpoint": u64(start),
+		"endCheckpoint":   u64(end),
+	}, err)}
+}
+
+// MoveFunction matches the transactions calling a Move function. An empty function
+// matches every function of the module, and an empty module every function of the
+// package.
+func MoveFunction(packageId, module, function string) TransactionFilter {
+	pkg, err := address("package", packageId)
+	value := map[string]interface{}{
+		"package":  pkg,
+		"module":   nil,
+		"function": nil,
+	}
+	errs := []error{err}
+	if module != "" {
+		value["module"] = module
+		errs = append(errs, identifier("module", module))
+	}
+	if function != "" {
+		value["function"] = function
+		errs = append(errs, identifier("function", function))
+		if module == "" {
+			errs = append(errs, errorf("function %q without a module", function))
+		}
+	}
+	return TransactionFilter{newFilter("MoveFunction", value, errs...)}
+}
+
+// InputObject matches the transactions taking the object as input.
+func InputObject(objectId string) TransactionFilter {
+	id, err := address("object", objectId)
+	return TransactionFilter{newFilter("InputObject", id, err)}
+}
+
+// ChangedObject matches the transactions creating, mutating or wrapping the object.
+func ChangedObject(objectId string) TransactionFilter {
+	id, err := address("object", objectId)
+	return TransactionFilter{newFilter("ChangedObject", id, err)}
+}
+
+// FromAddress matches the transactions sent by the address.
+func FromAddress(addr string) TransactionFilter {
+	from, err := address("sender", addr)
+	return TransactionFilter{newFilter("FromAddress", from, err)}
+}
+
+// ToAddress matches the transactions sending objects to the address.
+func ToAddress(addr string) TransactionFilter {
+	to, err := address("recipient", addr)
+	return TransactionFilter{newFilter("ToAddress", to, err)}
+}
+
+// FromAndToAddress matches the transactions sent by from that send objects to to.
+func FromAndToAddress(from, to string) TransactionFilter {
+	fromAddr, fromErr := address("sender", from)
+	toAddr, toErr := address("recipient", to)
+	return TransactionFilter{newFilter("FromAndToAddress", map[string]string{
+		"from": fromAddr,
+		"to":   toAddr,
+	}, fromErr, toErr)}
+}
+
+// FromOrToAddress matches the transactions sent by the address or sending objects to it.
+func FromOrToAddress(addr string) TransactionFilter {
+	a, err := address("party", addr)
+	return TransactionFilter{newFilter("FromOrToAddress", map[string]string{"addr": a}, err)}
+}
+
+// TransactionKind matches the transactions of a kind, such as `ProgrammableTransaction`.
+func TransactionKind(kind string) TransactionFilter {
+	return TransactionFilter{newFilter("TransactionKind", kind, identifier("transaction kind", kind))}
+}
+
+// TransactionKindIn matches the transactions of any of the kinds.
+func TransactionKindIn(kinds ...string) TransactionFilter {
+	errs := []error{}
+	if len(kinds) == 0 {
+		errs = append(errs, errorf("no transaction kinds"))
+	}
+	for _, kind := range kinds {
+		errs = append(errs, identifier("transaction kind", kind))
+	}
+	return TransactionFilter{newFilter("TransactionKindIn", kinds, errs...)}
+}
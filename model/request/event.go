@@ -4,7 +4,7 @@ type MgoGetEventsRequest struct {
 	Digest string `json:"digest"`
 }
 type MgoXQueryEventsRequest struct {
-	// the event query criteria. See Event filter documentation[https://docs.mangonet.io/mango-api-ref/#mgox_queryevents] for examples.
+	// the event query criteria, e.g. a filter.EventFilter. See Event filter documentation[https://docs.mangonet.io/mango-api-ref/#mgox_queryevents] for examples.
 	MgoEventFilter interface{} `json:"mgoEventFilter"`
 	// optional paging cursor
 	Cursor interface{} `json:"cursor"`
@@ -64,13 +64,15 @@ type MgoTransactionBlockOptions struct {
 	ShowInput          bool `json:"showInput,omitempty"`
 	ShowRawInput       bool `json:"showRawInput,omitempty"`
 	ShowEffects        bool `json:"showEffects,omitempty"`
+	ShowRawEffects     bool `json:"showRawEffects,omitempty"`
 	ShowEvents         bool `json:"showEvents,omitempty"`
 	ShowObjectChanges  bool `json:"showObjectChanges,omitempty"`
 	ShowBalanceChanges bool `json:"showBalanceChanges,omitempty"`
 }
 
 type MgoTransactionBlockResponseQuery struct {
-	TransactionFilter TransactionFilter          `json:"filter"`
+	// the transaction query criteria, a filter.TransactionFilter or a TransactionFilter
+	TransactionFilter interface{}                `json:"filter"`
 	Options           MgoTransactionBlockOptions `json:"options"`
 }
 
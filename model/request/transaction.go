@@ -28,8 +28,8 @@ type TransferObjectRequest struct {
 
 type SignAndExecuteTransactionBlockRequest struct {
 	TxnMetaData model.TxnMetaData
-	// the address private key to sign the transaction
-	Keypair *keypair.Keypair
+	// the key to sign the transaction, either a *keypair.Keypair or an external signer
+	Keypair keypair.KeySigner
 	Options TransactionBlockOptions `json:"options" yaml:"options"`
 	// The optional enumeration values are: `WaitForEffectsCert`, or `WaitForLocalExecution`
 	RequestType string `json:"requestType" yaml:"requestType"`
@@ -38,6 +38,7 @@ type TransactionBlockOptions struct {
 	ShowInput          bool `json:"showInput,omitempty"          yaml:"showInput"`
 	ShowRawInput       bool `json:"showRawInput,omitempty"       yaml:"showRawInput"`
 	ShowEffects        bool `json:"showEffects,omitempty"        yaml:"showEffects"`
+	ShowRawEffects     bool `json:"showRawEffects,omitempty"     yaml:"showRawEffects"`
 	ShowEvents         bool `json:"showEvents,omitempty"         yaml:"showEvents"`
 	ShowObjectChanges  bool `json:"showObjectChanges,omitempty"  yaml:"showObjectChanges"`
 	ShowBalanceChanges bool `json:"showBalanceChanges,omitempty" yaml:"showBalanceChanges"`
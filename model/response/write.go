@@ -1,12 +1,18 @@
 package response
 
-import "github.com/mangonet-labs/mgo-go-sdk/model"
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+)
 
 type MgoTransactionBlockResponse struct {
 	Digest                  string                 `json:"digest"                            yaml:"digest"`
 	Transaction             model.TransactionBlock `json:"transaction,omitempty"             yaml:"transaction"`
 	RawTransaction          string                 `json:"rawTransaction,omitempty"          yaml:"rawTransaction"`
 	Effects                 model.Effects          `json:"effects,omitempty"                 yaml:"effects"`
+	RawEffects              RawBytes               `json:"rawEffects,omitempty"              yaml:"rawEffects"`
 	Events                  []model.EventResponse  `json:"events,omitempty"                  yaml:"events"`
 	ObjectChanges           []model.ObjectChange   `json:"objectChanges,omitempty"           yaml:"objectChanges"`
 	BalanceChanges          []model.BalanceChanges `json:"balanceChanges,omitempty"          yaml:"balanceChanges"`
@@ -15,12 +21,43 @@ type MgoTransactionBlockResponse struct {
 	ConfirmedLocalExecution bool                   `json:"confirmedLocalExecution,omitempty" yaml:"confirmedLocalExecution"`
 }
 
+// RawBytes is a byte array the node encodes as an array of numbers, such as the BCS
+// bytes of the effects returned with ShowRawEffects. It also accepts base64 strings.
+type RawBytes []byte
+
+func (b RawBytes) MarshalJSON() ([]byte, error) {
+	numbers := make([]uint16, len(b))
+	for i, v := range b {
+		numbers[i] = uint16(v)
+	}
+	return json.Marshal(numbers)
+}
+
+func (b *RawBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		decoded, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return err
+		}
+		*b = decoded
+		return nil
+	}
+	var numbers []uint8
+	if err := json.Unmarshal(data, &numbers); err != nil {
+		return err
+	}
+	*b = numbers
+	return nil
+}
+
 type TransactionFilter map[string]interface{}
 
 type MgoTransactionBlockOptions struct {
 	ShowInput          bool `json:"showInput,omitempty"          yaml:"showInput"`
 	ShowRawInput       bool `json:"showRawInput,omitempty"       yaml:"showRawInput"`
 	ShowEffects        bool `json:"showEffects,omitempty"        yaml:"showEffects"`
+	ShowRawEffects     bool `json:"showRawEffects,omitempty"     yaml:"showRawEffects"`
 	ShowEvents         bool `json:"showEvents,omitempty"         yaml:"showEvents"`
 	ShowObjectChanges  bool `json:"showObjectChanges,omitempty"  yaml:"showObjectChanges"`
 	ShowBalanceChanges bool `json:"showBalanceChanges,omitempty" yaml:"showBalanceChanges"`
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+)
+
+func TestBatch(t *testing.T) {
+	var posts int
+	var ids []int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		// Answer out of order and leave a request unanswered, which testutil.Node never does.
+		var reqs []testutil.Request
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Answer in reverse order to check that responses are matched by ID.
+		rsps := make([]map[string]any, 0, len(reqs))
+		for i := len(reqs) - 1; i >= 0; i-- {
+			req := reqs[i]
+			ids = append(ids, req.ID)
+			rsp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+			switch req.Method {
+			case "mgo_getObject":
+				var id string
+				_ = json.Unmarshal(req.Params[0], &id)
+				if id == "0x404" {
+					rsp["error"] = map[string]any{"code": -32602, "message": "Could not find the referenced object"}
+				} else {
+					rsp["result"] = map[string]any{"data": map[string]any{"objectId": id, "version": "3"}}
+				}
+			case "mgox_getBalance":
+				rsp["result"] = map[string]any{"coinType": "0x2::mgo::MGO", "coinObjectCount": 2, "totalBalance": "500"}
+			case "mgo_getTransactionBlock":
+				var digest string
+				_ = json.Unmarshal(req.Params[0], &digest)
+				rsp["result"] = map[string]any{"digest": digest}
+			default:
+				continue
+			}
+			rsps = append(rsps, rsp)
+		}
+		_ = json.NewEncoder(w).Encode(rsps)
+	}))
+	defer server.Close()
+	cli := client.NewMgoClient(server.URL)
+
+	batch := cli.NewBatch()
+	object := batch.MgoGetObject(request.MgoGetObjectRequest{ObjectId: "0x5"})
+	missing := batch.MgoGetObject(request.MgoGetObjectRequest{ObjectId: "0x404"})
+	balance := batch.MgoXGetBalance(request.MgoXGetBalanceRequest{Owner: "0x1", CoinType: "0x2::mgo::MGO"})
+	txn := batch.MgoGetTransactionBlock(request.MgoGetTransactionBlockRequest{Digest: "digest"})
+	unanswered := client.BatchCall[string](batch, "mgo_getChainIdentifier")
+	if !errors.Is(object.Err, client.ErrBatchNotSent) {
+		t.Fatalf("expected a pending result, got %v", object.Err)
+	}
+
+	if err := batch.Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if posts != 1 || batch.Len() != 0 {
+		t.Fatalf("expected a single post, got %d", posts)
+	}
+	if object.Err != nil || object.Result.Data.ObjectId != "0x5" {
+		t.Fatalf("unexpected object %+v (%v)", object.Result, object.Err)
+	}
+	if !errors.Is(missing.Err, client.ErrObjectNotFound) {
+		t.Fatalf("expected object not found, got %v", missing.Err)
+	}
+	if balance.Err != nil || balance.Result.TotalBalance != "500" {
+		t.Fatalf("unexpected balance %+v (%v)", balance.Result, balance.Err)
+	}
+	if txn.Err != nil || txn.Result.Digest != "digest" {
+		t.Fatalf("unexpected transaction %+v (%v)", txn.Result, txn.Err)
+	}
+	if !errors.Is(unanswered.Err, client.ErrInvalidResponse) {
+		t.Fatalf("expected a missing response, got %v", unanswered.Err)
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] >= ids[i-1] {
+			t.Fatalf("expected increasing ids, got %v", ids)
+		}
+	}
+
+	posts = 0
+	batch.SetMaxSize(2)
+	for i := 0; i < 5; i++ {
+		batch.MgoGetObject(request.MgoGetObjectRequest{ObjectId: "0x5"})
+	}
+	if err := batch.Send(ctx); err != nil || posts != 3 {
+		t.Fatalf("expected 3 posts, got %d (%v)", posts, err)
+	}
+}
+
+func TestUniqueRequestIDs(t *testing.T) {
+	node := newNode(t, ok, "mgo_getTotalTransactionBlocks")
+	conn := httpconn.NewHttpConn(node.URL)
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := conn.Request(ctx, httpconn.Operation{Method: "mgo_getTotalTransactionBlocks"}); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+	seen := map[int64]bool{}
+	for _, req := range node.Requests() {
+		if seen[req.ID] {
+			t.Fatalf("duplicate request id %d", req.ID)
+		}
+		seen[req.ID] = true
+	}
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+)
+
+var ctx = context.Background()
+
+// getObject fetches an object from a node answering with answer, a *testutil.RPCError or
+// a *testutil.HTTPResponse, and returns the error.
+func getObject(t *testing.T, answer any) error {
+	node := testutil.NewNode(t)
+	node.Result("mgo_getObject", answer)
+	_, err := node.MgoClient().MgoGetObject(ctx, request.MgoGetObjectRequest{ObjectId: "0x5"})
+	return err
+}
+
+func TestRPCError(t *testing.T) {
+	err := getObject(t, &testutil.RPCError{
+		Code:    -32602,
+		Message: "Could not find the referenced object 0x5 at version None",
+		Data:    map[string]any{"object_id": "0x5"},
+	})
+
+	var rpcErr *client.RPCError
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("expected an rpc error, got %v", err)
+	}
+	if rpcErr.Code != -32602 || string(rpcErr.Data) != `{"object_id":"0x5"}` {
+		t.Fatalf("unexpected rpc error %+v", rpcErr)
+	}
+	if !errors.Is(err, client.ErrObjectNotFound) || !errors.Is(err, client.ErrInvalidParams) {
+		t.Fatalf("expected object not found and invalid params, got %v", err)
+	}
+	if errors.Is(err, client.ErrInsufficientGas) || errors.Is(err, client.ErrObjectEquivocated) {
+		t.Fatalf("unexpected error class for %v", err)
+	}
+
+	err = getObject(t, &testutil.RPCError{
+		Code:    -32002,
+		Message: "Transaction execution failed due to issues with transaction inputs, please review the errors and try again: Balance of gas object 10 is lower than the needed amount: 100.",
+	})
+	if !errors.Is(err, client.ErrInsufficientGas) {
+		t.Fatalf("expected insufficient gas, got %v", err)
+	}
+
+	for _, rpcErr := range []*testutil.RPCError{
+		{Code: -32602, Message: "Package 0x9 does not exist"},
+		{Code: -32002, Message: "Transaction execution failed: input object 0x5 was deleted"},
+		{Code: -32603, Message: "Could not find the referenced object 0x5 at version None"},
+	} {
+		if err := getObject(t, rpcErr); errors.Is(err, client.ErrObjectNotFound) {
+			t.Fatalf("unexpected object not found for %v", err)
+		}
+	}
+
+	err = getObject(t, &testutil.RPCError{
+		Code:    -32002,
+		Message: "Failed to sign transaction by a quorum of validators because of locked objects: ObjectLockConflict",
+	})
+	if !errors.Is(err, client.ErrObjectEquivocated) {
+		t.Fatalf("expected equivocated object, got %v", err)
+	}
+}
+
+func TestTransportErrors(t *testing.T) {
+	err := getObject(t, &testutil.HTTPResponse{StatusCode: http.StatusTooManyRequests, Body: "slow down"})
+	var httpErr *client.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests || string(httpErr.Body) != "slow down" {
+		t.Fatalf("expected an http error, got %v", err)
+	}
+
+	err = getObject(t, &testutil.HTTPResponse{Body: "<html>gateway</html>"})
+	if !errors.Is(err, client.ErrInvalidResponse) {
+		t.Fatalf("expected an invalid response, got %v", err)
+	}
+	var rpcErr *client.RPCError
+	if errors.As(err, &rpcErr) {
+		t.Fatal("an invalid response is not an rpc error")
+	}
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+
+	"golang.org/x/time/rate"
+)
+
+var fastRetry = httpconn.RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// newNode returns a node answering methods with handler, called with the number of
+// requests received so far.
+func newNode(t *testing.T, handler func(calls int) any, methods ...string) *testutil.Node {
+	node := testutil.NewNode(t)
+	for _, method := range methods {
+		node.Handle(method, func(*testutil.Request) any {
+			return handler(len(node.Requests()))
+		})
+	}
+	return node
+}
+
+func ok(int) any {
+	return "7"
+}
+
+func status(code int) func(int) any {
+	return func(int) any {
+		return &testutil.HTTPResponse{StatusCode: code}
+	}
+}
+
+func TestRetryAndFailover(t *testing.T) {
+	flaky := newNode(t, func(calls int) any {
+		if calls == 1 {
+			return &testutil.HTTPResponse{StatusCode: http.StatusBadGateway}
+		}
+		return "7"
+	}, "mgo_getTotalTransactionBlocks")
+	conn := httpconn.NewMultiEndpointHttpConn([]string{flaky.URL}, nil).SetRetryPolicy(fastRetry)
+	if _, err := conn.Request(ctx, httpconn.Operation{Method: "mgo_getTotalTransactionBlocks"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(flaky.Requests()) != 2 {
+		t.Fatalf("expected a retry, got %d calls", len(flaky.Requests()))
+	}
+
+	down := newNode(t, status(http.StatusServiceUnavailable), "mgo_getTotalTransactionBlocks")
+	backup := newNode(t, ok, "mgo_getTotalTransactionBlocks")
+	conn = httpconn.NewMultiEndpointHttpConn([]string{down.URL, backup.URL}, nil).
+		SetRetryPolicy(fastRetry).
+		SetHealthCooldown(time.Hour)
+	cli := client.NewMgoClientWithHttpConn(conn)
+	for i := 0; i < 3; i++ {
+		total, err := cli.MgoGetTotalTransactionBlocks(ctx)
+		if err != nil || total != 7 {
+			t.Fatalf("expected 7, got %d (%v)", total, err)
+		}
+	}
+	if len(down.Requests()) != 1 || len(backup.Requests()) != 3 {
+		t.Fatalf("expected the unhealthy endpoint to be skipped, got %d and %d calls", len(down.Requests()), len(backup.Requests()))
+	}
+	status := conn.Endpoints()
+	if status[0].Healthy || status[0].ConsecutiveFailures != 1 || !status[1].Healthy {
+		t.Fatalf("unexpected endpoint status %+v", status)
+	}
+}
+
+func TestRetryLimits(t *testing.T) {
+	failing := newNode(t, status(http.StatusInternalServerError), "mgo_getTotalTransactionBlocks", "mgo_executeTransactionBlock")
+	conn := httpconn.NewMultiEndpointHttpConn([]string{failing.URL}, nil).SetRetryPolicy(fastRetry)
+	_, err := conn.Request(ctx, httpconn.Operation{Method: "mgo_getTotalTransactionBlocks"})
+	var httpErr *httpconn.HTTPError
+	if !errors.As(err, &httpErr) || len(failing.Requests()) != 3 {
+		t.Fatalf("expected 3 attempts and an http error, got %d (%v)", len(failing.Requests()), err)
+	}
+
+	// A 500 may come after the transaction was submitted, so writes are not retried.
+	_, err = conn.Request(ctx, httpconn.Operation{Method: "mgo_executeTransactionBlock"})
+	if calls := failing.CallCount("mgo_executeTransactionBlock"); err == nil || calls != 1 {
+		t.Fatalf("expected a single attempt for a write, got %d", calls)
+	}
+
+	rejected := newNode(t, status(http.StatusTooManyRequests), "mgo_executeTransactionBlock")
+	conn = httpconn.NewMultiEndpointHttpConn([]string{rejected.URL}, nil).SetRetryPolicy(fastRetry)
+	_, _ = conn.Request(ctx, httpconn.Operation{Method: "mgo_executeTransactionBlock"})
+	if len(rejected.Requests()) != 3 {
+		t.Fatalf("expected a rejected write to be retried, got %d calls", len(rejected.Requests()))
+	}
+
+	rpcFailure := newNode(t, func(int) any {
+		return &testutil.RPCError{Code: -32602, Message: "invalid params"}
+	}, "mgo_getObject")
+	conn = httpconn.NewMultiEndpointHttpConn([]string{rpcFailure.URL}, nil).SetRetryPolicy(fastRetry)
+	_, err = conn.Request(ctx, httpconn.Operation{Method: "mgo_getObject"})
+	if !errors.Is(err, httpconn.ErrInvalidParams) || len(rpcFailure.Requests()) != 1 {
+		t.Fatalf("expected rpc errors not to be retried, got %d calls (%v)", len(rpcFailure.Requests()), err)
+	}
+}
+
+func TestTimeoutAndRateLimit(t *testing.T) {
+	slow := newNode(t, func(calls int) any {
+		if calls == 1 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		return "7"
+	}, "mgo_getTotalTransactionBlocks")
+	conn := httpconn.NewMultiEndpointHttpConn([]string{slow.URL}, nil).
+		SetRetryPolicy(fastRetry).
+		SetTimeout(20 * time.Millisecond)
+	if _, err := conn.Request(ctx, httpconn.Operation{Method: "mgo_getTotalTransactionBlocks"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(slow.Requests()) != 2 {
+		t.Fatalf("expected the timed out attempt to be retried, got %d calls", len(slow.Requests()))
+	}
+
+	// Waiting for the execution of a write can outlast the timeout of reads, and a write
+	// that timed out would not be retried.
+	execute := newNode(t, func(int) any {
+		time.Sleep(100 * time.Millisecond)
+		return map[string]any{"digest": "tx"}
+	}, "mgo_executeTransactionBlock", "mgo_getTotalTransactionBlocks")
+	conn = httpconn.NewMultiEndpointHttpConn([]string{execute.URL}, nil).
+		SetRetryPolicy(fastRetry).
+		SetTimeout(20 * time.Millisecond)
+	if _, err := conn.Request(ctx, httpconn.Operation{Method: "mgo_executeTransactionBlock"}); err != nil {
+		t.Fatalf("expected a slow write not to time out, got %v", err)
+	}
+	if _, err := conn.Request(ctx, httpconn.Operation{Method: "mgo_getTotalTransactionBlocks"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a slow read to time out, got %v", err)
+	}
+
+	fast := newNode(t, ok, "mgo_getTotalTransactionBlocks")
+	conn = httpconn.NewMultiEndpointHttpConn([]string{fast.URL}, nil).
+		SetRateLimiter(rate.NewLimiter(rate.Every(30*time.Millisecond), 1))
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := conn.Request(ctx, httpconn.Operation{Method: "mgo_getTotalTransactionBlocks"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("expected the rate limiter to delay requests, took %s", elapsed)
+	}
+}
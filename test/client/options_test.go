@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
+
+	"github.com/gorilla/websocket"
+)
+
+type tokenKey struct{}
+
+func TestClientOptions(t *testing.T) {
+	node := newNode(t, ok, "mgo_getTotalTransactionBlocks")
+
+	var calls []string
+	var statuses []int
+	cli := client.NewMgoClientWithOptions(node.URL,
+		client.WithHTTPClient(node.Client()),
+		client.WithHeader("X-Api-Key", "secret"),
+		client.WithHeaderFunc(func(ctx context.Context) http.Header {
+			token, _ := ctx.Value(tokenKey{}).(string)
+			return http.Header{"Authorization": {"Bearer " + token}}
+		}),
+		client.WithUserAgent("dashboard/1.0"),
+		client.WithTimeout(time.Second),
+		client.WithRateLimit(1000, 10),
+		client.WithRequestInterceptor(func(ctx context.Context, info *httpconn.RequestInfo) error {
+			calls = append(calls, strings.Join(info.Methods, ","))
+			info.Request.Header.Set("X-Attempt", "1")
+			return nil
+		}),
+		client.WithResponseInterceptor(func(ctx context.Context, info *httpconn.RequestInfo, rsp *httpconn.ResponseInfo) {
+			statuses = append(statuses, rsp.StatusCode)
+		}),
+	)
+
+	total, err := cli.MgoGetTotalTransactionBlocks(context.WithValue(ctx, tokenKey{}, "token"))
+	if err != nil || total != 7 {
+		t.Fatalf("expected 7, got %d (%v)", total, err)
+	}
+	headers := node.Requests()[0].Header
+	if headers.Get("X-Api-Key") != "secret" || headers.Get("Authorization") != "Bearer token" ||
+		headers.Get("User-Agent") != "dashboard/1.0" || headers.Get("X-Attempt") != "1" {
+		t.Fatalf("unexpected headers %v", headers)
+	}
+	if len(calls) != 1 || calls[0] != "mgo_getTotalTransactionBlocks" || len(statuses) != 1 || statuses[0] != http.StatusOK {
+		t.Fatalf("unexpected interceptor calls %v %v", calls, statuses)
+	}
+
+	rejected := errors.New("rejected")
+	cli = client.NewMgoClientWithOptions(node.URL,
+		client.WithRequestInterceptor(func(ctx context.Context, info *httpconn.RequestInfo) error {
+			return rejected
+		}),
+	)
+	if _, err := cli.MgoGetTotalTransactionBlocks(ctx); !errors.Is(err, rejected) || len(node.Requests()) != 1 {
+		t.Fatalf("expected the interceptor to abort the request, got %v", err)
+	}
+}
+
+func TestWebsocketClientOptions(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	headers := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	wsUrl := "ws" + strings.TrimPrefix(server.URL, "http")
+	_, err := client.NewMgoWebsocketClientWithOptions(ctx, wsUrl,
+		client.WithHeader("X-Api-Key", "secret"),
+		client.WithUserAgent("dashboard/1.0"),
+		client.WithTimeout(time.Second),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := <-headers
+	if header.Get("X-Api-Key") != "secret" || header.Get("User-Agent") != "dashboard/1.0" {
+		t.Fatalf("unexpected headers %v", header)
+	}
+
+	if _, err := client.NewMgoWebsocketClientWithOptions(ctx, "ws://127.0.0.1:1"); err == nil {
+		t.Fatal("expected a dial error")
+	}
+}
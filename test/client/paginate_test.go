@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/model/response"
+)
+
+func TestPaginateAllPages(t *testing.T) {
+	_, cli := newFakeQueryNode(t, 7)
+	req := request.MgoXQueryEventsRequest{MgoEventFilter: request.EventFilterBySender{Sender: "0x1"}, Limit: 3}
+
+	events, err := client.Collect(cli.MgoXQueryEventsIter(ctx, req, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 7 {
+		t.Fatalf("expected 7 events, got %d", len(events))
+	}
+	for i, event := range events {
+		if event.Id.TxDigest != fmt.Sprintf("tx%d", i) {
+			t.Fatalf("expected tx%d, got %s", i, event.Id.TxDigest)
+		}
+	}
+}
+
+func TestPaginateMaxItemsAndBreak(t *testing.T) {
+	_, cli := newFakeQueryNode(t, 10)
+	req := request.MgoXQueryTransactionBlocksRequest{Limit: 3}
+
+	txns, err := client.Collect(cli.MgoXQueryTransactionBlocksIter(ctx, req, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txns) != 4 || txns[3].Digest != "tx3" {
+		t.Fatalf("expected the first 4 transactions, got %v", txns)
+	}
+
+	var seen []string
+	cli.MgoXQueryTransactionBlocksIter(ctx, req, 0)(func(txn response.MgoTransactionBlockResponse, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen = append(seen, txn.Digest)
+		return txn.Digest != "tx5"
+	})
+	if len(seen) != 6 {
+		t.Fatalf("expected iteration to stop after tx5, got %v", seen)
+	}
+}
+
+func TestPaginateContext(t *testing.T) {
+	_, cli := newFakeQueryNode(t, 10)
+	iterCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var got []string
+	var iterErr error
+	cli.MgoXQueryTransactionBlocksIter(iterCtx, request.MgoXQueryTransactionBlocksRequest{Limit: 2}, 0)(func(txn response.MgoTransactionBlockResponse, err error) bool {
+		if err != nil {
+			iterErr = err
+			return false
+		}
+		got = append(got, txn.Digest)
+		if txn.Digest == "tx1" {
+			cancel()
+		}
+		return true
+	})
+	if !errors.Is(iterErr, context.Canceled) {
+		t.Fatalf("expected the iteration to stop with its context, got %v", iterErr)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected the first page only, got %v", got)
+	}
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/client/stream"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/model/response"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+)
+
+// fakeQueryNode serves mgox_queryEvents and mgox_queryTransactionBlocks over a growing
+// list of items.
+type fakeQueryNode struct {
+	mu    sync.Mutex
+	items int
+}
+
+func (n *fakeQueryNode) add(count int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.items += count
+}
+
+func newFakeQueryNode(t *testing.T, items int) (*fakeQueryNode, *client.Client) {
+	node := &fakeQueryNode{items: items}
+	rpc := testutil.NewNode(t)
+	rpc.Handle("mgox_queryEvents", node.query)
+	rpc.Handle("mgox_queryTransactionBlocks", node.query)
+	return node, rpc.MgoClient()
+}
+
+func (n *fakeQueryNode) query(req *testutil.Request) any {
+	start := 0
+	var cursor response.EventId
+	if err := json.Unmarshal(req.Params[1], &cursor); err == nil && cursor.TxDigest != "" {
+		fmt.Sscanf(cursor.TxDigest, "tx%d", &start)
+		start++
+	}
+	var digest string
+	if err := json.Unmarshal(req.Params[1], &digest); err == nil && digest != "" {
+		fmt.Sscanf(digest, "tx%d", &start)
+		start++
+	}
+	var limit int
+	_ = json.Unmarshal(req.Params[2], &limit)
+
+	n.mu.Lock()
+	end := min(start+limit, n.items)
+	hasNextPage := end < n.items
+	n.mu.Unlock()
+
+	data := []map[string]any{}
+	var nextCursor any
+	for i := start; i < end; i++ {
+		digest := fmt.Sprintf("tx%d", i)
+		if req.Method == "mgox_queryEvents" {
+			nextCursor = map[string]any{"txDigest": digest, "eventSeq": "0"}
+			data = append(data, map[string]any{"id": nextCursor})
+		} else {
+			nextCursor = digest
+			data = append(data, map[string]any{"digest": digest})
+		}
+	}
+	return map[string]any{"data": data, "nextCursor": nextCursor, "hasNextPage": hasNextPage}
+}
+
+func TestEventStreamResumes(t *testing.T) {
+	node, cli := newFakeQueryNode(t, 10)
+	store := stream.NewFileStore(filepath.Join(t.TempDir(), "checkpoints.json"))
+	cfg := stream.Config{Key: "events", Store: store, PageSize: 3, PollInterval: time.Millisecond}
+	req := request.MgoXQueryEventsRequest{MgoEventFilter: request.EventFilterBySender{Sender: "0x1"}}
+
+	var seen []string
+	crash := errors.New("crash")
+	err := stream.Events(ctx, cli, req, cfg, func(ctx context.Context, event response.MgoEventResponse) error {
+		if event.Id.TxDigest == "tx4" {
+			return crash
+		}
+		seen = append(seen, event.Id.TxDigest)
+		return nil
+	})
+	if !errors.Is(err, crash) {
+		t.Fatalf("expected the handler error, got %v", err)
+	}
+	if cursor, _, _ := store.Load(ctx, "events"); cursor != `{"txDigest":"tx3","eventSeq":"0"}` {
+		t.Fatalf("unexpected saved cursor %s", cursor)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	err = stream.Events(streamCtx, cli, req, cfg, func(ctx context.Context, event response.MgoEventResponse) error {
+		seen = append(seen, event.Id.TxDigest)
+		switch event.Id.TxDigest {
+		case "tx9":
+			node.add(2)
+		case "tx11":
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the stream to stop with its context, got %v", err)
+	}
+	if len(seen) != 12 {
+		t.Fatalf("expected 12 events, got %v", seen)
+	}
+	for i, digest := range seen {
+		if digest != fmt.Sprintf("tx%d", i) {
+			t.Fatalf("expected events in order without gaps or duplicates, got %v", seen)
+		}
+	}
+}
+
+func TestTransactionStreamChan(t *testing.T) {
+	_, cli := newFakeQueryNode(t, 5)
+	store := stream.NewMemoryStore()
+	_ = store.Save(ctx, "transactions", "tx1")
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	txns, errs := stream.TransactionsChan(streamCtx, cli, request.MgoXQueryTransactionBlocksRequest{}, stream.Config{
+		Key:          "transactions",
+		Store:        store,
+		PageSize:     2,
+		PollInterval: time.Millisecond,
+	})
+	for i := 2; i < 5; i++ {
+		message := receive(t, txns)
+		if message.Item.Digest != fmt.Sprintf("tx%d", i) {
+			t.Fatalf("expected tx%d, got %s", i, message.Item.Digest)
+		}
+		// tx4 is received but not handled before the stream stops
+		if i < 4 {
+			message.Ack()
+		}
+	}
+	cancel()
+	if err := receive(t, errs); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the stream to stop with its context, got %v", err)
+	}
+	if cursor, _, _ := store.Load(ctx, "transactions"); cursor != "tx3" {
+		t.Fatalf("expected the cursor of the last acknowledged transaction, got %s", cursor)
+	}
+
+	streamCtx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	txns, _ = stream.TransactionsChan(streamCtx, cli, request.MgoXQueryTransactionBlocksRequest{}, stream.Config{
+		Key:          "transactions",
+		Store:        store,
+		PollInterval: time.Millisecond,
+	})
+	if message := receive(t, txns); message.Item.Digest != "tx4" {
+		t.Fatalf("expected the unacknowledged transaction to be delivered again, got %s", message.Item.Digest)
+	}
+}
@@ -0,0 +1,314 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/client/httpconn"
+	"github.com/mangonet-labs/mgo-go-sdk/client/wsconn"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/model/response"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+
+	"github.com/gorilla/websocket"
+)
+
+var fastReconnect = httpconn.RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, Multiplier: 2}
+
+// fakeWsNode answers subscriptions with increasing IDs and lets the test push
+// notifications and drop connections.
+type fakeWsNode struct {
+	*httptest.Server
+	mu      sync.Mutex
+	nextSub int64
+	conns   []*websocket.Conn
+	methods []string
+	subs    chan int64
+}
+
+func newFakeWsNode(t *testing.T) *fakeWsNode {
+	node := &fakeWsNode{nextSub: 100, subs: make(chan int64, 16)}
+	upgrader := websocket.Upgrader{}
+	node.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		node.mu.Lock()
+		node.conns = append(node.conns, conn)
+		node.mu.Unlock()
+		for {
+			var req testutil.Request
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			node.mu.Lock()
+			node.methods = append(node.methods, req.Method)
+			rsp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+			if strings.HasPrefix(req.Method, "mgox_subscribe") {
+				node.nextSub++
+				rsp["result"] = node.nextSub
+				node.subs <- node.nextSub
+			} else {
+				rsp["result"] = true
+			}
+			err := conn.WriteJSON(rsp)
+			node.mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(node.Close)
+	return node
+}
+
+func (n *fakeWsNode) url() string {
+	return "ws" + strings.TrimPrefix(n.URL, "http")
+}
+
+func (n *fakeWsNode) notify(subscription int64, result any) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	conn := n.conns[len(n.conns)-1]
+	_ = conn.WriteJSON(map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "mgox_subscribeEvent",
+		"params":  map[string]any{"subscription": subscription, "result": result},
+	})
+}
+
+func (n *fakeWsNode) dropConnections() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, conn := range n.conns {
+		conn.Close()
+	}
+}
+
+func (n *fakeWsNode) calledMethods() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.methods...)
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a message")
+	}
+	var zero T
+	return zero
+}
+
+func TestReconnectingSubscription(t *testing.T) {
+	node := newFakeWsNode(t)
+	ws, err := client.NewMgoWebsocketClientWithOptions(ctx, node.url(), client.WithReconnectPolicy(fastReconnect))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := make(chan response.MgoEventResponse, 10)
+	sub, err := ws.(client.ISubscriptionAPI).NewEventSubscription(subCtx, request.MgoSubscribeEventsRequest{
+		MgoEventFilter: request.EventFilterBySender{Sender: "0x1"},
+	}, events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := receive(t, node.subs)
+	if sub.ID() != first {
+		t.Fatalf("expected subscription %d, got %d", first, sub.ID())
+	}
+
+	node.notify(first, map[string]any{"sender": "0x1", "id": map[string]any{"txDigest": "a", "eventSeq": "0"}})
+	if event := receive(t, events); event.Id.TxDigest != "a" {
+		t.Fatalf("unexpected event %+v", event)
+	}
+
+	// A malformed notification is reported without stopping the subscription.
+	node.notify(first, "not an event")
+	receive(t, sub.Errors())
+
+	node.dropConnections()
+	second := receive(t, node.subs)
+	deadline := time.Now().Add(5 * time.Second)
+	for sub.ID() != second && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	node.notify(second, map[string]any{"sender": "0x1", "id": map[string]any{"txDigest": "b", "eventSeq": "0"}})
+	if event := receive(t, events); event.Id.TxDigest != "b" {
+		t.Fatalf("unexpected event after reconnecting %+v", event)
+	}
+
+	if err := sub.Unsubscribe(ctx); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-sub.Done():
+	default:
+		t.Fatal("expected the subscription to be stopped")
+	}
+	if sub.Err() != nil {
+		t.Fatalf("unexpected error %v", sub.Err())
+	}
+	deadline = time.Now().Add(5 * time.Second)
+	for !contains(node.calledMethods(), "mgox_unsubscribeEvent") && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !contains(node.calledMethods(), "mgox_unsubscribeEvent") {
+		t.Fatalf("expected an unsubscribe call, got %v", node.calledMethods())
+	}
+}
+
+func TestSubscriptionStopsWithContext(t *testing.T) {
+	node := newFakeWsNode(t)
+	ws := client.NewMgoWebsocketClient(node.url())
+
+	subCtx, cancel := context.WithCancel(ctx)
+	sub, err := ws.(client.ISubscriptionAPI).NewTransactionSubscription(subCtx, request.MgoSubscribeTransactionsRequest{}, make(chan response.MgoEffects))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	select {
+	case <-sub.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the subscription to stop with its context")
+	}
+
+	if _, err := client.NewMgoWebsocketClient("ws://127.0.0.1:1").(client.ISubscriptionAPI).NewEventSubscription(ctx, request.MgoSubscribeEventsRequest{}, nil); err == nil {
+		t.Fatal("expected a dial error instead of exiting")
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMultiplexedSubscriptions(t *testing.T) {
+	node := newFakeWsNode(t)
+	ws := client.NewMgoWebsocketClient(node.url())
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := make(chan response.MgoEventResponse, 10)
+	effects := make(chan response.MgoEffects, 10)
+	eventSub, err := ws.(client.ISubscriptionAPI).NewEventSubscription(subCtx, request.MgoSubscribeEventsRequest{}, events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	effectsSub, err := ws.(client.ISubscriptionAPI).NewTransactionSubscription(subCtx, request.MgoSubscribeTransactionsRequest{}, effects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eventSub.ID() == effectsSub.ID() {
+		t.Fatalf("expected distinct subscriptions, got %d", eventSub.ID())
+	}
+
+	for i := 0; i < 20; i++ {
+		node.notify(effectsSub.ID(), map[string]any{"transactionDigest": "effects"})
+		node.notify(eventSub.ID(), map[string]any{"sender": "0x1", "id": map[string]any{"txDigest": "event", "eventSeq": "0"}})
+	}
+	for i := 0; i < 20; i++ {
+		if event := receive(t, events); event.Id.TxDigest != "event" {
+			t.Fatalf("unexpected event %+v", event)
+		}
+		if effect := receive(t, effects); effect.TransactionDigest != "effects" {
+			t.Fatalf("unexpected effects %+v", effect)
+		}
+	}
+
+	node.mu.Lock()
+	conns := len(node.conns)
+	node.mu.Unlock()
+	if conns != 1 {
+		t.Fatalf("expected a single connection, got %d", conns)
+	}
+}
+
+func TestConcurrentWebsocketCalls(t *testing.T) {
+	node := newFakeWsNode(t)
+	conn := wsconn.NewWsConn(node.url())
+	defer conn.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := conn.Call(ctx, wsconn.CallOp{Method: "mgo_getChainIdentifier"})
+			if err == nil && string(result) != "true" {
+				err = errors.New("unexpected result " + string(result))
+			}
+			if err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+	if len(node.calledMethods()) != 20 {
+		t.Fatalf("expected 20 calls, got %d", len(node.calledMethods()))
+	}
+}
+
+func TestSlowSubscriptionHandler(t *testing.T) {
+	node := newFakeWsNode(t)
+	conn := wsconn.NewWsConn(node.url())
+	defer conn.Close()
+
+	release := make(chan struct{})
+	defer close(release)
+	sub, err := conn.Subscribe(ctx, wsconn.CallOp{Method: "mgox_subscribeEvent"}, "mgox_unsubscribeEvent", func(ctx context.Context, result json.RawMessage) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 300; i++ {
+		node.notify(sub.ID(), map[string]any{"sender": "0x1"})
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, err := conn.Call(callCtx, wsconn.CallOp{Method: "mgo_getChainIdentifier"}); err != nil {
+		t.Fatalf("expected calls not to wait for a slow handler, got %v", err)
+	}
+	select {
+	case err := <-sub.Errors():
+		if !errors.Is(err, wsconn.ErrNotificationDropped) {
+			t.Fatalf("expected a dropped notification, got %v", err)
+		}
+	case <-callCtx.Done():
+		t.Fatal("expected dropped notifications to be reported")
+	}
+	if err := sub.Unsubscribe(callCtx); err != nil {
+		t.Fatalf("expected to unsubscribe while the handler is busy, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/model/filter"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction"
+)
+
+const (
+	owner  = "0x4e7ac37a2e2a0bd1d04bd2db1d1c1c4b38b58ec31f1da86fb8f7f1b8ec0e1eb4"
+	digest = "5uKUUtqgd7aocMBrPUqu4yXjHhKooVHeNqQ1HyM8e6BC"
+)
+
+func TestEventFilterJSON(t *testing.T) {
+	tests := []struct {
+		filter filter.EventFilter
+		want   string
+	}{
+		{filter.Sender(owner), `{"Sender":"` + owner + `"}`},
+		{filter.Transaction(digest), `{"Transaction":"` + digest + `"}`},
+		{filter.MoveEventType("0x0002::coin::CoinMinted<0x2::mgo::MGO>"), `{"MoveEventType":"0x2::coin::CoinMinted<0x2::mgo::MGO>"}`},
+		{filter.TimeRange(1, 2), `{"TimeRange":{"endTime":"2","startTime":"1"}}`},
+		{
+			filter.Any(filter.Package("0x2"), filter.And(filter.Sender(owner), filter.MoveModule("0x2", "pay"))),
+			`{"Any":[{"Package":"0x0000000000000000000000000000000000000000000000000000000000000002"},` +
+				`{"And":[{"Sender":"` + owner + `"},` +
+				`{"MoveModule":{"module":"pay","package":"0x0000000000000000000000000000000000000000000000000000000000000002"}}]}]}`,
+		},
+	}
+	for _, test := range tests {
+		assertJSON(t, test.filter, test.want)
+	}
+}
+
+func TestTransactionFilterJSON(t *testing.T) {
+	tests := []struct {
+		filter filter.TransactionFilter
+		want   string
+	}{
+		{filter.FromAddress(owner), `{"FromAddress":"` + owner + `"}`},
+		{filter.Checkpoint(7), `{"Checkpoint":"7"}`},
+		{filter.CheckpointRange(7, 9), `{"CheckpointRange":{"endCheckpoint":"9","startCheckpoint":"7"}}`},
+		{filter.MoveFunction("0x2", "coin", ""), `{"MoveFunction":{"function":null,"module":"coin","package":"0x0000000000000000000000000000000000000000000000000000000000000002"}}`},
+		{filter.FromAndToAddress(owner, "0x2"), `{"FromAndToAddress":{"from":"` + owner + `","to":"0x0000000000000000000000000000000000000000000000000000000000000002"}}`},
+	}
+	for _, test := range tests {
+		assertJSON(t, test.filter, test.want)
+	}
+}
+
+func TestInvalidFilters(t *testing.T) {
+	invalid := []interface{ Validate() error }{
+		filter.Sender("0xzz"),
+		filter.Transaction("not a digest"),
+		filter.MoveEventType("0x2::coin"),
+		filter.MoveModule("0x2", "1pay"),
+		filter.All(),
+		filter.Or(filter.Sender(owner), filter.Package("")),
+		filter.TimeRange(2, 1),
+		filter.CheckpointRange(9, 7),
+		filter.MoveFunction("0x2", "", "transfer"),
+		filter.ToAddress("0x" + owner),
+		filter.TransactionFilter{},
+	}
+	for i, f := range invalid {
+		err := f.Validate()
+		if !errors.Is(err, filter.ErrInvalidFilter) {
+			t.Errorf("filter %d: expected ErrInvalidFilter, got %v", i, err)
+		}
+		if _, err := json.Marshal(f); err == nil {
+			t.Errorf("filter %d: expected serializing to fail", i)
+		}
+	}
+	if err := filter.MoveEventType("0x2::coin").Validate(); !errors.Is(err, transaction.ErrInvalidTypeTag) {
+		t.Errorf("expected the type tag error, got %v", err)
+	}
+}
+
+func assertJSON(t *testing.T, v interface{}, want string) {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, expected interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %s, got %s", want, b)
+	}
+}
@@ -0,0 +1,103 @@
+package framework
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/framework"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+)
+
+var ctx = context.Background()
+
+const coinType = "0x2::coin::Coin<0x2::mgo::MGO>"
+
+func newObjectNode(t *testing.T, objectType string, object any) *client.Client {
+	node := testutil.NewNode(t)
+	node.Result("mgo_getObject", map[string]any{
+		"data": map[string]any{
+			"objectId": "0x5",
+			"version":  "1",
+			"type":     objectType,
+			"bcs": map[string]any{
+				"dataType":          "moveObject",
+				"type":              objectType,
+				"hasPublicTransfer": true,
+				"version":           1,
+				"bcsBytes":          bcs.ToBase64(bcs.MustMarshal(object)),
+			},
+		},
+	})
+	return node.MgoClient()
+}
+
+func TestGetCoin(t *testing.T) {
+	want := framework.Coin{Id: framework.UID{Id: model.MgoAddressBytes{31: 5}}, Balance: framework.Balance{Value: 1000}}
+	cli := newObjectNode(t, coinType, want)
+
+	var coin framework.Coin
+	data, err := framework.GetObject(ctx, cli, "0x5", &coin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coin != want || data.Type != coinType {
+		t.Fatalf("unexpected coin %+v", coin)
+	}
+	if coin.Id.String() != "0x0000000000000000000000000000000000000000000000000000000000000005" {
+		t.Fatalf("unexpected id %s", coin.Id)
+	}
+
+	if _, err := framework.GetObjectAs(ctx, cli, "0x5", "0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x2::mgo::MGO>", &coin); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := framework.GetObjectAs(ctx, cli, "0x5", "0x2::coin::Coin<0x7::usdc::USDC>", &coin); !errors.Is(err, framework.ErrTypeMismatch) {
+		t.Fatalf("expected a type mismatch for another coin type, got %v", err)
+	}
+}
+
+func TestTypeMismatch(t *testing.T) {
+	cli := newObjectNode(t, "0x2::table::Table<address, u64>", framework.Table{Size: 3})
+
+	var coin framework.Coin
+	if _, err := framework.GetObject(ctx, cli, "0x5", &coin); !errors.Is(err, framework.ErrTypeMismatch) {
+		t.Fatalf("expected a type mismatch, got %v", err)
+	}
+
+	var table framework.Table
+	if _, err := framework.GetObject(ctx, cli, "0x5", &table); err != nil || table.Size != 3 {
+		t.Fatalf("unexpected table %+v: %v", table, err)
+	}
+}
+
+type hero struct {
+	Id    framework.UID
+	Name  string
+	Power uint64
+	Sword *framework.UID `bcs:"optional"`
+}
+
+func (hero) MoveType() string {
+	return "0x7::game::Hero"
+}
+
+func TestCustomStruct(t *testing.T) {
+	want := hero{Name: "mango", Power: 9, Sword: &framework.UID{Id: model.MgoAddressBytes{1}}}
+	cli := newObjectNode(t, "0x7::game::Hero", want)
+
+	var got hero
+	if _, err := framework.GetObject(ctx, cli, "0x5", &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != want.Name || got.Power != want.Power || got.Sword == nil || *got.Sword != *want.Sword {
+		t.Fatalf("unexpected hero %+v", got)
+	}
+
+	var short framework.Bag
+	if _, err := framework.GetObjectAs(ctx, cli, "0x5", "0x7::game::Hero", &short); err == nil {
+		t.Fatal("expected decoding into a shorter layout to fail")
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/hdwallet"
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+)
+
+const mnemonic = "film crazy soon outside stand loop subway crumble thrive popular green nuclear struggle pistol arm wife phrase warfare march wheat nephew ask sunny firm"
+
+var ctx = context.Background()
+
+func newWallet(t *testing.T, passphrase string) *hdwallet.Wallet {
+	w, err := hdwallet.New(mnemonic, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func TestDeriveMatchesMnemonicKeypair(t *testing.T) {
+	w := newWallet(t, "")
+	for _, scheme := range []config.Scheme{config.Ed25519Flag, config.Secp256k1Flag, config.Secp256r1Flag} {
+		want, err := keypair.NewKeypairWithMnemonic(mnemonic, scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+		account, err := w.Derive(scheme, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if account.Path != config.DERIVATION_PATH[scheme] || account.Keypair.MgoAddress() != want.MgoAddress() {
+			t.Fatalf("scheme %d: expected %s at %s, got %s at %s", scheme, want.MgoAddress(), config.DERIVATION_PATH[scheme], account.Keypair.MgoAddress(), account.Path)
+		}
+
+		other, err := w.Derive(scheme, 1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		protected, err := newWallet(t, "passphrase").Derive(scheme, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if other.Keypair.MgoAddress() == want.MgoAddress() || protected.Keypair.MgoAddress() == want.MgoAddress() {
+			t.Fatalf("scheme %d: expected other indices and passphrases to derive other keys", scheme)
+		}
+	}
+}
+
+func TestDeriveForPath(t *testing.T) {
+	w := newWallet(t, "")
+	if _, err := w.DeriveForPath(config.Ed25519Flag, "m/44'/938'/0'/0'/5'"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.DeriveForPath(config.Secp256k1Flag, "m/54'/938'/2'/0/5"); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		scheme config.Scheme
+		path   string
+	}{
+		{config.Ed25519Flag, "m/44'/938'/0'/0/0"},
+		{config.Ed25519Flag, "m/44'/938'/0'/0'/2147483648'"},
+		{config.Secp256k1Flag, "m/44'/938'/0'/0/0"},
+		{config.Secp256k1Flag, "m/74'/938'/0'/0/0"},
+		{config.Secp256k1Flag, "m/54'/938'/0'/0/2147483648"},
+		{config.Secp256k1Flag, "m/54'/938'/4294967296'/0/0"},
+		{config.Secp256r1Flag, "m/74'/938'/0'"},
+		{config.Secp256r1Flag, "m/74'/938'/0'/2147483648/0"},
+	} {
+		if _, err := w.DeriveForPath(tc.scheme, tc.path); !errors.Is(err, hdwallet.ErrInvalidPath) {
+			t.Fatalf("scheme %d: expected %s to be invalid, got %v", tc.scheme, tc.path, err)
+		}
+	}
+	if _, err := hdwallet.New("not a mnemonic", ""); err == nil {
+		t.Fatal("expected an invalid mnemonic to fail")
+	}
+}
+
+func TestPathIndexOutOfRange(t *testing.T) {
+	if path, err := hdwallet.Path(config.Ed25519Flag, 1<<31-1, 1<<31-1); err != nil || path != "m/44'/938'/2147483647'/0'/2147483647'" {
+		t.Fatalf("expected the largest index to be valid, got %s, %v", path, err)
+	}
+	w := newWallet(t, "")
+	for _, index := range [][2]uint32{{1 << 31, 0}, {0, 1 << 31}, {1<<32 - 1, 1<<32 - 1}} {
+		for _, scheme := range []config.Scheme{config.Ed25519Flag, config.Secp256k1Flag, config.Secp256r1Flag} {
+			if _, err := hdwallet.Path(scheme, index[0], index[1]); !errors.Is(err, hdwallet.ErrInvalidPath) {
+				t.Fatalf("scheme %d: expected indices %v to be invalid, got %v", scheme, index, err)
+			}
+			if _, err := w.Derive(scheme, index[0], index[1]); !errors.Is(err, hdwallet.ErrInvalidPath) {
+				t.Fatalf("scheme %d: expected deriving indices %v to fail, got %v", scheme, index, err)
+			}
+		}
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	w := newWallet(t, "")
+	used := map[string]bool{}
+	for _, index := range []uint32{0, 2, 5} {
+		account, err := w.Derive(config.Ed25519Flag, index, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		used[account.Keypair.MgoAddress()] = true
+	}
+	checked := 0
+	accounts, err := w.Discover(ctx, config.Ed25519Flag, 3, func(ctx context.Context, address string) (bool, error) {
+		checked++
+		return used[address], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 3 || accounts[0].AccountIndex != 0 || accounts[1].AccountIndex != 2 || accounts[2].AccountIndex != 5 {
+		t.Fatalf("unexpected accounts %+v", accounts)
+	}
+	if checked != 9 {
+		t.Fatalf("expected discovery to stop after 3 unused accounts, checked %d", checked)
+	}
+
+	accounts, err = w.Discover(ctx, config.Ed25519Flag, 2, func(ctx context.Context, address string) (bool, error) {
+		return used[address], nil
+	})
+	if err != nil || len(accounts) != 2 {
+		t.Fatalf("expected a smaller gap limit to miss account 5, got %d accounts: %v", len(accounts), err)
+	}
+}
+
+func TestTransactionActivity(t *testing.T) {
+	active, err := newWallet(t, "").Derive(config.Secp256k1Flag, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := testutil.NewNode(t)
+	node.Handle("mgox_queryTransactionBlocks", func(req *testutil.Request) any {
+		var query struct {
+			Filter struct {
+				ToAddress string `json:"ToAddress"`
+			} `json:"filter"`
+		}
+		_ = json.Unmarshal(req.Params[0], &query)
+		data := []any{}
+		if query.Filter.ToAddress == active.Keypair.MgoAddress() {
+			data = append(data, map[string]any{"digest": "tx"})
+		}
+		return map[string]any{"data": data, "hasNextPage": false}
+	})
+
+	hasActivity := hdwallet.TransactionActivity(node.MgoClient())
+	if used, err := hasActivity(ctx, active.Keypair.MgoAddress()); err != nil || !used {
+		t.Fatalf("expected an address that received a transaction to be used: %v", err)
+	}
+	unused, err := newWallet(t, "").Derive(config.Secp256k1Flag, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used, err := hasActivity(ctx, unused.Keypair.MgoAddress()); err != nil || used {
+		t.Fatalf("expected an address without transactions to be unused: %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/account/keystore"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+)
+
+func TestKeystoreCLIFormat(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mgo.keystore")
+	privateKey := make([]byte, 32)
+	privateKey[31] = 1
+	entry := base64.StdEncoding.EncodeToString(append([]byte{byte(config.Ed25519Flag)}, privateKey...))
+	if err := os.WriteFile(path, []byte(`["`+entry+`"]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ks, err := keystore.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := ks.Keys()
+	if len(keys) != 1 || keys[0].Alias == "" {
+		t.Fatalf("unexpected keys %+v", keys)
+	}
+	want, err := keypair.NewKeypairWithPrivateKey(config.Ed25519Flag, hex.EncodeToString(privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys[0].MgoAddress != want.MgoAddress() {
+		t.Fatalf("expected %s, got %s", want.MgoAddress(), keys[0].MgoAddress)
+	}
+
+	if err := ks.Add(testutil.NewKeypair(t, config.Secp256k1Flag), "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Add(testutil.NewKeypair(t, config.Secp256r1Flag), "alice"); !errors.Is(err, keystore.ErrAliasExists) {
+		t.Fatalf("expected a duplicate alias to fail, got %v", err)
+	}
+	if err := ks.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []string
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &entries); err != nil || len(entries) != 2 || entries[0] != entry {
+		t.Fatalf("expected the keystore to stay a JSON array of keys, got %s", data)
+	}
+	var aliases []map[string]string
+	data, _ = os.ReadFile(filepath.Join(dir, "mgo.aliases"))
+	if err := json.Unmarshal(data, &aliases); err != nil || len(aliases) != 2 || aliases[1]["alias"] != "alice" {
+		t.Fatalf("unexpected aliases file %s", data)
+	}
+
+	reloaded, err := keystore.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, err := reloaded.Get("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byAddress, err := reloaded.Get(alice.MgoAddress()); err != nil || byAddress.MgoAddress() != alice.MgoAddress() {
+		t.Fatalf("expected to find alice by address: %v", err)
+	}
+	if alice.Scheme != config.Secp256k1Flag || reloaded.Keys()[0].Alias != keys[0].Alias {
+		t.Fatalf("unexpected reloaded keys %+v", reloaded.Keys())
+	}
+}
+
+func TestKeystoreImportExport(t *testing.T) {
+	ks, err := keystore.Load(filepath.Join(t.TempDir(), "mgo.keystore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp := testutil.NewKeypair(t, config.Ed25519Flag)
+	imported, err := ks.Import(kp.MgoPrivateKey(), "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.MgoAddress() != kp.MgoAddress() {
+		t.Fatalf("expected %s, got %s", kp.MgoAddress(), imported.MgoAddress())
+	}
+	if _, err := ks.Import(kp.MgoPrivateKey(), "carol"); !errors.Is(err, keystore.ErrKeyExists) {
+		t.Fatalf("expected a duplicate key to fail, got %v", err)
+	}
+	exported, err := ks.Export("bob")
+	if err != nil || exported != kp.MgoPrivateKey() {
+		t.Fatalf("unexpected export %s: %v", exported, err)
+	}
+
+	if err := ks.SetAlias(kp.MgoAddress(), "carol"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Remove("carol"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Get("bob"); !errors.Is(err, keystore.ErrKeyNotFound) {
+		t.Fatalf("expected the key to be removed, got %v", err)
+	}
+}
+
+func TestEncryptedKeystore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mgo.keystore")
+	ks, err := keystore.LoadEncrypted(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp := testutil.NewKeypair(t, config.Ed25519Flag)
+	if err := ks.Add(kp, "main"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := keystore.Load(path); !errors.Is(err, keystore.ErrEncrypted) {
+		t.Fatalf("expected ErrEncrypted, got %v", err)
+	}
+	if _, err := keystore.LoadEncrypted(path, "wrong"); !errors.Is(err, keystore.ErrWrongPassword) {
+		t.Fatalf("expected ErrWrongPassword, got %v", err)
+	}
+	reloaded, err := keystore.LoadEncrypted(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := reloaded.Get("main"); err != nil || got.MgoAddress() != kp.MgoAddress() {
+		t.Fatalf("unexpected key: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected the keystore to be private, got %v", info.Mode())
+	}
+}
+
+func TestBase64WithFlag(t *testing.T) {
+	kp := testutil.NewKeypair(t, config.Secp256k1Flag)
+	encoded, err := keypair.EncodeBase64WithFlag(kp.Scheme, kp.PrivateKeyHex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheme, privateKey, err := keypair.DecodeBase64WithFlag(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := keypair.NewKeypairWithPrivateKey(scheme, privateKey)
+	if err != nil || decoded.MgoAddress() != kp.MgoAddress() {
+		t.Fatalf("expected the key to round trip: %v", err)
+	}
+	if _, _, err := keypair.DecodeBase64WithFlag(""); err == nil {
+		t.Fatal("expected an empty key to fail")
+	}
+
+	multiSig, err := keypair.EncodeBase64WithFlag(config.MultiSigFlag, kp.PrivateKeyHex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := keypair.DecodeBase64WithFlag(multiSig); err == nil {
+		t.Fatal("expected a key with the multisig flag to fail")
+	}
+	multiSig, err = keypair.EncodeMgoPrivateKey(config.MultiSigFlag, kp.PrivateKeyHex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := keypair.DecodeMgoPrivateKey(multiSig); err == nil {
+		t.Fatal("expected a bech32 key with the multisig flag to fail")
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/account/multisig"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+)
+
+func newKeypairs(t *testing.T) []*keypair.Keypair {
+	var keypairs []*keypair.Keypair
+	for _, scheme := range []config.Scheme{config.Ed25519Flag, config.Secp256k1Flag, config.Ed25519Flag} {
+		keypairs = append(keypairs, testutil.NewKeypair(t, scheme))
+	}
+	return keypairs
+}
+
+func newMultiSigPublicKey(t *testing.T, keypairs []*keypair.Keypair) *multisig.MultiSigPublicKey {
+	var publicKeys []multisig.WeightedPublicKey
+	for _, key := range keypairs {
+		publicKeys = append(publicKeys, multisig.WeightedPublicKey{
+			Scheme:    key.Scheme,
+			PublicKey: key.PublicKeyBytes(),
+			Weight:    1,
+		})
+	}
+	publicKey, err := multisig.NewMultiSigPublicKey(2, publicKeys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return publicKey
+}
+
+func TestMultiSigTwoOfThree(t *testing.T) {
+	keypairs := newKeypairs(t)
+	publicKey := newMultiSigPublicKey(t, keypairs)
+
+	txn := &model.TxnMetaData{TxBytes: base64.StdEncoding.EncodeToString([]byte("transaction data"))}
+	var signatures []string
+	for _, key := range []*keypair.Keypair{keypairs[2], keypairs[1]} {
+		signed, err := key.SignTransactionBlock(txn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signatures = append(signatures, signed.Signature)
+	}
+
+	combined, err := publicKey.CombineSignatures(signatures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	combinedBytes, err := base64.StdEncoding.DecodeString(combined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := multisig.VerifyTransactionBlock([]byte("transaction data"), combinedBytes); err != nil {
+		t.Fatal(err)
+	}
+	if !keypair.VerifyTransactionBlock([]byte("transaction data"), combinedBytes) {
+		t.Fatal("expected keypair to verify the multisig signature")
+	}
+	if keypair.VerifyTransactionBlock([]byte("other data"), combinedBytes) {
+		t.Fatal("expected the signature not to verify other data")
+	}
+
+	address, err := keypair.ExtractSignerMgoAddress(combinedBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != publicKey.MgoAddress() {
+		t.Fatalf("expected address %s, got %s", publicKey.MgoAddress(), address)
+	}
+
+	parsed, err := multisig.ParseMultiSig(combinedBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Bitmap != 0b110 || len(parsed.Signatures) != 2 || parsed.Signatures[0].Scheme != config.Secp256k1Flag {
+		t.Fatalf("unexpected multisig %+v", parsed)
+	}
+
+	if _, err := publicKey.CombineSignatures(signatures[:1]); !errors.Is(err, multisig.ErrInsufficientWeight) {
+		t.Fatalf("expected insufficient weight, got %v", err)
+	}
+	if _, err := publicKey.CombineSignatures([]string{signatures[0], signatures[0]}); !errors.Is(err, multisig.ErrDuplicateSigner) {
+		t.Fatalf("expected duplicate signer, got %v", err)
+	}
+	outsider := testutil.NewKeypair(t, config.Ed25519Flag)
+	signed, err := outsider.SignTransactionBlock(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := publicKey.CombineSignatures([]string{signatures[0], signed.Signature}); !errors.Is(err, multisig.ErrUnknownSigner) {
+		t.Fatalf("expected unknown signer, got %v", err)
+	}
+}
+
+func TestMultiSigTamperedBitmap(t *testing.T) {
+	keypairs := newKeypairs(t)
+	publicKey := newMultiSigPublicKey(t, keypairs)
+
+	txn := &model.TxnMetaData{TxBytes: base64.StdEncoding.EncodeToString([]byte("transaction data"))}
+	var signatures []string
+	for _, key := range keypairs[:2] {
+		signed, err := key.SignTransactionBlock(txn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signatures = append(signatures, signed.Signature)
+	}
+	combined, err := publicKey.CombineSignatures(signatures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	combinedBytes, _ := base64.StdEncoding.DecodeString(combined)
+	parsed, err := multisig.ParseMultiSig(combinedBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed.Bitmap = 0b101
+	if err := multisig.VerifyTransactionBlock([]byte("transaction data"), parsed.Serialize()); err == nil {
+		t.Fatal("expected a tampered bitmap to fail verification")
+	}
+	parsed.Bitmap = 0b011
+	parsed.PublicKey.Threshold = 3
+	if err := multisig.VerifyTransactionBlock([]byte("transaction data"), parsed.Serialize()); err == nil {
+		t.Fatal("expected an unreachable threshold to be rejected")
+	}
+}
+
+func TestMultiSigPublicKeyValidation(t *testing.T) {
+	keypairs := newKeypairs(t)
+	key := multisig.WeightedPublicKey{Scheme: keypairs[0].Scheme, PublicKey: keypairs[0].PublicKeyBytes(), Weight: 1}
+
+	if _, err := multisig.NewMultiSigPublicKey(2, key); !errors.Is(err, multisig.ErrInvalidThreshold) {
+		t.Fatalf("expected invalid threshold, got %v", err)
+	}
+	if _, err := multisig.NewMultiSigPublicKey(1, key, key); !errors.Is(err, multisig.ErrInvalidPublicKeys) {
+		t.Fatalf("expected duplicate public keys to be rejected, got %v", err)
+	}
+	if _, err := multisig.NewMultiSigPublicKey(1); !errors.Is(err, multisig.ErrInvalidPublicKeys) {
+		t.Fatalf("expected empty public keys to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/account/signer/remote"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	ctx := context.Background()
+	for _, scheme := range []config.Scheme{config.Ed25519Flag, config.Secp256k1Flag, config.Secp256r1Flag} {
+		key := testutil.NewKeypair(t, scheme)
+		server := httptest.NewServer(authorized(remote.NewHandler(key)))
+
+		if _, err := remote.NewSigner(ctx, server.URL, server.Client(), nil); err == nil {
+			t.Fatal("expected an unauthorized request to fail")
+		}
+		signer, err := remote.NewSigner(ctx, server.URL, server.Client(), http.Header{"Authorization": {"Bearer token"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if signer.SignatureScheme() != scheme || signer.MgoAddress() != key.MgoAddress() {
+			t.Fatalf("expected signer %s, got %s", key.MgoAddress(), signer.MgoAddress())
+		}
+
+		txBytes := []byte("transaction data")
+		signed, err := keypair.SignTransactionBlock(ctx, signer, &model.TxnMetaData{TxBytes: base64.StdEncoding.EncodeToString(txBytes)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature, _ := base64.StdEncoding.DecodeString(signed.Signature)
+		if !keypair.VerifyTransactionBlock(txBytes, signature) {
+			t.Fatal("transaction signature does not verify")
+		}
+
+		personal, err := keypair.SignPersonalMessage(ctx, signer, []byte("hello world"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !keypair.VerifyPersonalMessage([]byte("hello world"), personal) {
+			t.Fatal("personal message signature does not verify")
+		}
+		server.Close()
+	}
+}
+
+func authorized(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
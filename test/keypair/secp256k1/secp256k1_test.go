@@ -27,3 +27,18 @@ func TestSecp256k1(t *testing.T) {
 	t.Log(newsig.PublicKeyHex())
 
 }
+
+func TestSignatureLength(t *testing.T) {
+	sig, err := secp256k1.NewSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// About 1 in 128 signatures has an r or s with a leading zero byte.
+	for i := 0; i < 1000; i++ {
+		message := []byte{byte(i), byte(i >> 8)}
+		signature := sig.Sign(message)
+		if len(signature) != 64 || !secp256k1.Verify(sig.PublicKeyBytes(), message, signature) {
+			t.Fatalf("message %d: expected a valid 64 bytes signature, got %d bytes", i, len(signature))
+		}
+	}
+}
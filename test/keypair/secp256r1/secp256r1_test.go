@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/account/signer/secp256r1"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+)
+
+func TestSecp256r1(t *testing.T) {
+	sig, err := secp256r1.NewSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sig.PublicKeyBytes()) != config.Secp256r1PublicKeyLength {
+		t.Fatalf("expected compressed public key, got %d bytes", len(sig.PublicKeyBytes()))
+	}
+
+	imported, err := secp256r1.NewSignerByHex(sig.PrivateKeyHex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.PublicKeyHex() != sig.PublicKeyHex() {
+		t.Fatal("public key does not match after import")
+	}
+
+	halfOrder, _ := new(big.Int).SetString("7fffffff800000007fffffffffffffffde737d56d38bcf4279dce5617e3192a8", 16)
+	for i := 0; i < 16; i++ {
+		message := []byte{byte(i)}
+		signature := sig.Sign(message)
+		if new(big.Int).SetBytes(signature[32:]).Cmp(halfOrder) > 0 {
+			t.Fatal("expected a low s signature")
+		}
+		if !secp256r1.Verify(sig.PublicKeyBytes(), message, signature) {
+			t.Fatal("signature does not verify")
+		}
+		if secp256r1.Verify(sig.PublicKeyBytes(), []byte("other"), signature) {
+			t.Fatal("signature verifies another message")
+		}
+	}
+
+	if _, err := secp256r1.NewSignerByHex("0x00"); err == nil {
+		t.Fatal("expected a short private key to be rejected")
+	}
+}
+
+func TestSecp256r1Slip10(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := secp256r1.NewMasterKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(master.Key) != "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2" ||
+		hex.EncodeToString(master.ChainCode) != "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea" {
+		t.Fatalf("unexpected master key %x %x", master.Key, master.ChainCode)
+	}
+	child, err := master.Derive(secp256r1.FirstHardenedIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(child.Key) != "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c" ||
+		hex.EncodeToString(child.ChainCode) != "3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11" {
+		t.Fatalf("unexpected m/0' key %x %x", child.Key, child.ChainCode)
+	}
+
+	if _, err := secp256r1.DeriveForPath(`m/44'/938'/0'/0'/0'`, seed); err != secp256r1.ErrInvalidPath {
+		t.Fatalf("expected invalid path, got %v", err)
+	}
+}
+
+func TestSecp256r1Keypair(t *testing.T) {
+	mnemonic := "result crisp session latin must fruit genuine question prevent start coconut brave speak student dismiss"
+	key, err := keypair.NewKeypairWithMnemonic(mnemonic, config.Secp256r1Flag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := keypair.NewKeypairWithMnemonic(mnemonic, config.Secp256r1Flag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.MgoAddress() != again.MgoAddress() {
+		t.Fatal("derivation is not deterministic")
+	}
+
+	imported, err := keypair.NewKeypairWithMgoPrivateKey(key.MgoPrivateKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.Scheme != config.Secp256r1Flag || imported.MgoAddress() != key.MgoAddress() {
+		t.Fatal("keypair does not round trip through its mgo private key")
+	}
+
+	personal := key.SignPersonalMessage([]byte("hello world"))
+	if personal[0] != byte(config.Secp256r1Flag) || !keypair.VerifyPersonalMessage([]byte("hello world"), personal) {
+		t.Fatal("personal message signature does not verify")
+	}
+
+	txBytes := []byte("transaction data")
+	signed, err := key.SignTransactionBlock(&model.TxnMetaData{TxBytes: base64.StdEncoding.EncodeToString(txBytes)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, _ := base64.StdEncoding.DecodeString(signed.Signature)
+	if !keypair.VerifyTransactionBlock(txBytes, signature) {
+		t.Fatal("transaction signature does not verify")
+	}
+	address, err := keypair.ExtractSignerMgoAddress(signature)
+	if err != nil || address != key.MgoAddress() {
+		t.Fatalf("expected signer %s, got %s (%v)", key.MgoAddress(), address, err)
+	}
+}
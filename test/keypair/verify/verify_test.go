@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/account/multisig"
+	"github.com/mangonet-labs/mgo-go-sdk/account/verify"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+)
+
+var schemes = []config.Scheme{config.Ed25519Flag, config.Secp256k1Flag, config.Secp256r1Flag}
+
+func TestPersonalMessage(t *testing.T) {
+	message := []byte("sign in to example.com, nonce 42")
+	for _, scheme := range schemes {
+		kp := testutil.NewKeypair(t, scheme)
+		signature := base64.StdEncoding.EncodeToString(kp.SignPersonalMessage(message))
+
+		result, err := verify.PersonalMessage(message, signature, strings.ToUpper(kp.MgoAddress()[2:]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Valid || result.Scheme != scheme || result.MgoAddress != kp.MgoAddress() || string(result.PublicKey) != string(kp.PublicKeyBytes()) {
+			t.Fatalf("scheme %d: unexpected result %+v", scheme, result)
+		}
+
+		result, err = verify.PersonalMessage(message, signature, testutil.NewKeypair(t, scheme).MgoAddress())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Valid || !result.SignatureValid || result.AddressMatches {
+			t.Fatalf("scheme %d: expected a signature of another address to be invalid, got %+v", scheme, result)
+		}
+
+		result, err = verify.PersonalMessage([]byte("another message"), signature, kp.MgoAddress())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Valid || result.SignatureValid || !result.AddressMatches {
+			t.Fatalf("scheme %d: expected a signature of another message to be invalid, got %+v", scheme, result)
+		}
+	}
+}
+
+func TestTransactionBlock(t *testing.T) {
+	txBytes := []byte("transaction data")
+	txn := &model.TxnMetaData{TxBytes: base64.StdEncoding.EncodeToString(txBytes)}
+	for _, scheme := range schemes {
+		kp := testutil.NewKeypair(t, scheme)
+		signed, err := kp.SignTransactionBlock(txn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := verify.TransactionBlock(txBytes, signed.Signature, kp.MgoAddress())
+		if err != nil || !result.Valid {
+			t.Fatalf("scheme %d: expected the signature to be valid, got %+v: %v", scheme, result, err)
+		}
+		if result, err := verify.PersonalMessage(txBytes, signed.Signature, kp.MgoAddress()); err != nil || result.SignatureValid {
+			t.Fatalf("scheme %d: expected a transaction signature not to verify as a personal message: %v", scheme, err)
+		}
+	}
+}
+
+func TestMultiSig(t *testing.T) {
+	keypairs := []*keypair.Keypair{testutil.NewKeypair(t, config.Ed25519Flag), testutil.NewKeypair(t, config.Secp256k1Flag)}
+	var publicKeys []multisig.WeightedPublicKey
+	for _, kp := range keypairs {
+		publicKeys = append(publicKeys, multisig.WeightedPublicKey{Scheme: kp.Scheme, PublicKey: kp.PublicKeyBytes(), Weight: 1})
+	}
+	publicKey, err := multisig.NewMultiSigPublicKey(1, publicKeys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txBytes := []byte("transaction data")
+	signed, err := keypairs[1].SignTransactionBlock(&model.TxnMetaData{TxBytes: base64.StdEncoding.EncodeToString(txBytes)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	combined, err := publicKey.CombineSignatures([]string{signed.Signature})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := verify.TransactionBlock(txBytes, combined, publicKey.MgoAddress())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || result.Scheme != config.MultiSigFlag || string(result.PublicKey) != string(publicKey.Marshal()) {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if result, err := verify.TransactionBlock(txBytes, combined, keypairs[1].MgoAddress()); err != nil || result.Valid {
+		t.Fatalf("expected a multisig signature not to be valid for a member address: %v", err)
+	}
+}
+
+func TestMalformedSignatures(t *testing.T) {
+	kp := testutil.NewKeypair(t, config.Secp256k1Flag)
+	sig := kp.SignPersonalMessage([]byte("hello"))
+	for name, malformed := range map[string][]byte{
+		"empty":          {},
+		"flag only":      sig[:1],
+		"truncated":      sig[:len(sig)-1],
+		"too long":       append(append([]byte{}, sig...), 0),
+		"unknown scheme": append([]byte{0x7f}, sig[1:]...),
+		"wrong scheme":   append([]byte{byte(config.Secp256r1Flag)}, sig[1:len(sig)-1]...),
+		"multisig":       append([]byte{byte(config.MultiSigFlag)}, sig[1:]...),
+	} {
+		encoded := base64.StdEncoding.EncodeToString(malformed)
+		if _, err := verify.PersonalMessage([]byte("hello"), encoded, kp.MgoAddress()); !errors.Is(err, verify.ErrInvalidSignature) {
+			t.Fatalf("%s: expected ErrInvalidSignature, got %v", name, err)
+		}
+		if keypair.VerifyPersonalMessage([]byte("hello"), malformed) {
+			t.Fatalf("%s: expected the signature not to verify", name)
+		}
+	}
+	if _, err := verify.PersonalMessage([]byte("hello"), "not base64!", kp.MgoAddress()); !errors.Is(err, verify.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+	if _, err := verify.PersonalMessage([]byte("hello"), base64.StdEncoding.EncodeToString(sig), "0xzz"); !errors.Is(err, verify.ErrInvalidAddress) {
+		t.Fatalf("expected ErrInvalidAddress, got %v", err)
+	}
+	if keypair.GetSignatureScheme(nil) != "" || keypair.ParseSignatureInfo(sig[:10], "Secp256k1") != nil {
+		t.Fatal("expected malformed signatures to be rejected")
+	}
+	if _, err := keypair.ExtractSignerMgoAddress(nil); err == nil {
+		t.Fatal("expected an empty signature to fail")
+	}
+}
+
+func TestPublicKeyToMgoAddress(t *testing.T) {
+	for _, scheme := range schemes {
+		kp := testutil.NewKeypair(t, scheme)
+		address, err := keypair.PublicKeyToMgoAddress(kp.PublicKeyBytes(), scheme)
+		if err != nil || address != kp.MgoAddress() {
+			t.Fatalf("scheme %d: expected %s, got %s: %v", scheme, kp.MgoAddress(), address, err)
+		}
+	}
+	if _, err := keypair.PublicKeyToMgoAddress(make([]byte, 32), config.Secp256k1Flag); err == nil {
+		t.Fatal("expected a public key of the wrong length to fail")
+	}
+	if _, err := keypair.PublicKeyToMgoAddress(make([]byte, 32), config.MultiSigFlag); err == nil {
+		t.Fatal("expected the multisig scheme to fail")
+	}
+}
@@ -0,0 +1,81 @@
+package ptb_build
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/model/response"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction"
+	"github.com/mr-tron/base58"
+)
+
+// TestDigestKnownAnswers pins the digests of fixed bytes, computed independently as
+// base58(keccak256(prefix || bytes)), so a change of prefix, hash or encoding fails.
+// test/read checks the transaction digest against one returned by a node.
+func TestDigestKnownAnswers(t *testing.T) {
+	if got := transaction.TransactionDigestFromBytes([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}); got != "A8zeT1bgRQ5ykAGtuyh92bVcPSB5GpSeaar5UEJ7NHAp" {
+		t.Fatalf("unexpected transaction digest %s", got)
+	}
+	if got := transaction.TransactionEffectsDigest([]byte{1, 2, 255}); got != "3WFkvChd76Y2yTFkbYjMo214LVGAobHcEhaosc3NJMxT" {
+		t.Fatalf("unexpected effects digest %s", got)
+	}
+	if got := transaction.ObjectDigest([]byte{7}); got != "7wshiFibT5bbNhFNSPYckymNtiCd7QxJ3Z53nLbTGMYy" {
+		t.Fatalf("unexpected object digest %s", got)
+	}
+}
+
+func TestTransactionDigest(t *testing.T) {
+	tx := newParseTestTransaction(t)
+	req, err := tx.ToMgoExecuteTransactionBlockRequest(ctx, request.MgoTransactionBlockOptions{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	txBytes, err := bcs.FromBase64(req.TxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := transaction.TransactionDigestFromBytes(txBytes)
+
+	fromRequest, err := transaction.TransactionDigestFromBase64(req.TxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromData, err := tx.Data.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromTx, err := tx.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromRequest != want || fromData != want || fromTx != want {
+		t.Fatalf("expected digest %s, got %s, %s and %s", want, fromRequest, fromData, fromTx)
+	}
+	if decoded, _ := base58.Decode(string(want)); len(decoded) != 32 {
+		t.Fatalf("expected a 32-byte digest, got %d bytes", len(decoded))
+	}
+
+	if _, err := transaction.NewTransaction().Digest(); err == nil {
+		t.Fatal("expected the digest of an incomplete transaction to fail")
+	}
+}
+
+func TestTransactionEffectsDigest(t *testing.T) {
+	var rsp response.MgoTransactionBlockResponse
+	if err := json.Unmarshal([]byte(`{"digest":"x","rawEffects":[1,2,255]}`), &rsp); err != nil {
+		t.Fatal(err)
+	}
+	if string(rsp.RawEffects) != "\x01\x02\xff" {
+		t.Fatalf("unexpected raw effects %v", rsp.RawEffects)
+	}
+	if got := transaction.TransactionEffectsDigest(rsp.RawEffects); got != "3WFkvChd76Y2yTFkbYjMo214LVGAobHcEhaosc3NJMxT" {
+		t.Fatalf("unexpected effects digest %s", got)
+	}
+
+	b, err := json.Marshal(rsp.RawEffects)
+	if err != nil || string(b) != "[1,2,255]" {
+		t.Fatalf("unexpected encoding %s: %v", b, err)
+	}
+}
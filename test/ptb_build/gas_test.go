@@ -0,0 +1,170 @@
+package ptb_build
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction"
+	"github.com/mr-tron/base58"
+)
+
+var ctx = context.Background()
+
+func testDigest(b byte) string {
+	return base58.Encode(bytes.Repeat([]byte{b}, 32))
+}
+
+func testObjectId(b byte) string {
+	return fmt.Sprintf("0x%064x", b)
+}
+
+func coin(id byte, balance string, lockedUntilEpoch uint64) map[string]any {
+	return map[string]any{
+		"coinType":         "0x2::mgo::MGO",
+		"coinObjectId":     testObjectId(id),
+		"version":          "7",
+		"digest":           testDigest(id),
+		"balance":          balance,
+		"lockedUntilEpoch": lockedUntilEpoch,
+	}
+}
+
+func TestGasCoinSelection(t *testing.T) {
+	node := testutil.NewNode(t)
+	cli := node.MgoClient()
+	node.Result("mgox_getReferenceGasPrice", "1000")
+	node.Result("mgo_getProtocolConfig", map[string]any{
+		"attributes": map[string]any{"max_gas_payment_objects": map[string]string{"u32": "2"}},
+	})
+	node.Result("mgox_getLatestMgoSystemState", map[string]any{"epoch": "10"})
+	node.Result("mgo_multiGetObjects", []any{map[string]any{"data": map[string]any{
+		"objectId": testObjectId(2),
+		"version":  "7",
+		"digest":   testDigest(2),
+		"owner":    map[string]any{"AddressOwner": testObjectId(9)},
+	}}})
+	pages := []map[string]any{
+		{
+			"data":        []any{coin(1, "100", 0), coin(2, "900000", 0), coin(3, "40000000", 20)},
+			"nextCursor":  testObjectId(3),
+			"hasNextPage": true,
+		},
+		{
+			"data":        []any{coin(4, "30000000", 5), coin(5, "20000000", 0)},
+			"hasNextPage": false,
+		},
+	}
+	page := 0
+	node.Handle("mgox_getCoins", func(*testutil.Request) any {
+		defer func() { page++ }()
+		return pages[page]
+	})
+
+	signer := testutil.NewKeypair(t, config.Ed25519Flag)
+	tx := transaction.NewTransaction().SetMgoClient(cli).SetSigner(signer)
+	tx.TransferObjects([]transaction.Argument{tx.Object(testObjectId(2))}, tx.Pure(signer.MgoAddress()))
+
+	if _, err := tx.ToMgoExecuteTransactionBlockRequest(ctx, request.MgoTransactionBlockOptions{}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	payment := *tx.Data.V1.GasData.Payment
+	if len(payment) != 2 {
+		t.Fatalf("expected 2 gas coins, got %d", len(payment))
+	}
+	for i, id := range []byte{4, 5} {
+		if got := transaction.ConvertMgoAddressBytesToString(payment[i].ObjectId); got != model.MgoAddress(testObjectId(id)) {
+			t.Fatalf("gas coin %d: expected %s, got %s", i, testObjectId(id), got)
+		}
+	}
+	if node.CallCount("mgox_getCoins") != 2 {
+		t.Fatalf("expected 2 coin pages, got %d", node.CallCount("mgox_getCoins"))
+	}
+}
+
+func TestGasCoinSelectionInsufficient(t *testing.T) {
+	node := testutil.NewNode(t)
+	cli := node.MgoClient()
+	node.Result("mgox_getReferenceGasPrice", "1000")
+	node.Result("mgo_getProtocolConfig", map[string]any{"attributes": map[string]any{}})
+	node.Result("mgox_getCoins", map[string]any{
+		"data":        []any{coin(1, "100", 0)},
+		"hasNextPage": false,
+	})
+
+	signer := testutil.NewKeypair(t, config.Ed25519Flag)
+	tx := transaction.NewTransaction().SetMgoClient(cli).SetSigner(signer)
+	tx.TransferObjects([]transaction.Argument{tx.Gas()}, tx.Pure(signer.MgoAddress()))
+
+	_, err := tx.ToMgoExecuteTransactionBlockRequest(ctx, request.MgoTransactionBlockOptions{}, "")
+	if !errors.Is(err, transaction.ErrInsufficientGasCoins) {
+		t.Fatalf("expected ErrInsufficientGasCoins, got %v", err)
+	}
+}
+
+func TestGasBudgetEstimation(t *testing.T) {
+	node := testutil.NewNode(t)
+	cli := node.MgoClient()
+	node.Result("mgox_getReferenceGasPrice", "1000")
+	node.Result("mgo_getProtocolConfig", map[string]any{"attributes": map[string]any{}})
+	node.Result("mgo_dryRunTransactionBlock", map[string]any{
+		"effects": map[string]any{
+			"status": map[string]any{"status": "success"},
+			"gasUsed": map[string]any{
+				"computationCost": "1000000",
+				"storageCost":     "2000000",
+				"storageRebate":   "1000000",
+			},
+		},
+	})
+	node.Result("mgox_getCoins", map[string]any{
+		"data":        []any{coin(1, "5000000", 0)},
+		"hasNextPage": false,
+	})
+
+	signer := testutil.NewKeypair(t, config.Ed25519Flag)
+	tx := transaction.NewTransaction().SetMgoClient(cli).SetSigner(signer).SetGasBudgetEstimation(20)
+	tx.TransferObjects([]transaction.Argument{tx.Gas()}, tx.Pure(signer.MgoAddress()))
+
+	if _, err := tx.ToMgoExecuteTransactionBlockRequest(ctx, request.MgoTransactionBlockOptions{}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if budget := *tx.Data.V1.GasData.Budget; budget != 2400000 {
+		t.Fatalf("expected budget 2400000, got %d", budget)
+	}
+	if len(*tx.Data.V1.GasData.Payment) != 1 {
+		t.Fatalf("expected 1 gas coin, got %d", len(*tx.Data.V1.GasData.Payment))
+	}
+}
+
+func TestGasBudgetEstimationDryRunFailure(t *testing.T) {
+	node := testutil.NewNode(t)
+	cli := node.MgoClient()
+	node.Result("mgox_getReferenceGasPrice", "1000")
+	node.Result("mgo_getProtocolConfig", map[string]any{"attributes": map[string]any{}})
+	node.Result("mgo_dryRunTransactionBlock", map[string]any{
+		"effects": map[string]any{
+			"status": map[string]any{"status": "failure", "error": "InsufficientCoinBalance in command 0"},
+		},
+	})
+
+	signer := testutil.NewKeypair(t, config.Ed25519Flag)
+	tx := transaction.NewTransaction().SetMgoClient(cli).SetSigner(signer).SetGasBudgetEstimation(10)
+	tx.TransferObjects([]transaction.Argument{tx.Gas()}, tx.Pure(signer.MgoAddress()))
+
+	_, err := tx.ToMgoExecuteTransactionBlockRequest(ctx, request.MgoTransactionBlockOptions{}, "")
+	var dryRunErr *transaction.DryRunError
+	if !errors.As(err, &dryRunErr) {
+		t.Fatalf("expected DryRunError, got %v", err)
+	}
+	if dryRunErr.Status != "failure" {
+		t.Fatalf("unexpected dry run status %s", dryRunErr.Status)
+	}
+}
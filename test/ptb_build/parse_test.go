@@ -0,0 +1,146 @@
+package ptb_build
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction"
+)
+
+func newParseTestTransaction(t *testing.T) *transaction.Transaction {
+	payment, err := transaction.NewMgoObjectRef(model.MgoAddress(testObjectId(7)), "3", model.ObjectDigest(testDigest(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owned, err := transaction.NewMgoObjectRef(model.MgoAddress(testObjectId(4)), "9", model.ObjectDigest(testDigest(4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sharedId, err := transaction.ConvertMgoAddressStringToBytes(model.MgoAddress(testObjectId(5)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeArguments, err := transaction.ParseTypeTags("0x2::mgo::MGO", "vector<u8>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := transaction.NewTransaction().
+		SetSigner(testutil.NewKeypair(t, config.Ed25519Flag)).
+		SetGasPrice(1000).
+		SetGasBudget(2000000).
+		SetGasPayment([]transaction.MgoObjectRef{*payment}).
+		SetExpiration(transaction.TransactionExpiration{Epoch: new(uint64)})
+	shared := tx.Object(transaction.CallArg{Object: &transaction.ObjectArg{SharedObject: &transaction.SharedObjectRef{
+		ObjectId:             *sharedId,
+		InitialSharedVersion: 12,
+		Mutable:              true,
+	}}})
+	coin := tx.Object(transaction.CallArg{Object: &transaction.ObjectArg{ImmOrOwnedObject: owned}})
+
+	split := tx.SplitCoins(tx.Gas(), []transaction.Argument{tx.Pure(uint64(100)), tx.Pure(uint64(200))})
+	tx.MergeCoins(coin, []transaction.Argument{{NestedResult: &transaction.NestedResult{Index: 0, ResultIndex: 1}}})
+	vecType := "0x2::coin::Coin<0x2::mgo::MGO>"
+	vec := tx.MakeMoveVec(&vecType, []transaction.Argument{coin})
+	tx.MoveCall(model.MgoAddress(testPackage), "pool", "deposit", typeArguments, []transaction.Argument{shared, vec, split})
+	ticket := tx.Publish([][]byte{{0xa1, 0x1c, 0xeb, 0x0b}, {1, 2, 3}}, []model.MgoAddress{"0x1", "0x2"})
+	tx.Upgrade([][]byte{{4, 5}}, []model.MgoAddress{"0x2"}, model.MgoAddress(testPackage), ticket)
+	tx.TransferObjects([]transaction.Argument{{NestedResult: &transaction.NestedResult{Index: 0, ResultIndex: 0}}}, tx.Pure(testObjectId(9)))
+
+	return tx
+}
+
+func TestParseTransactionData(t *testing.T) {
+	tx := newParseTestTransaction(t)
+	req, err := tx.ToMgoExecuteTransactionBlockRequest(ctx, request.MgoTransactionBlockOptions{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := transaction.ParseTransactionData(req.TxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := data.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bcs.ToBase64(encoded) != req.TxBytes {
+		t.Fatalf("transaction data did not round trip:\n%s\n%s", req.TxBytes, bcs.ToBase64(encoded))
+	}
+
+	v1 := data.V1
+	if v1.Expiration == nil || v1.Expiration.Epoch == nil || *v1.Expiration.Epoch != 0 {
+		t.Fatalf("unexpected expiration %+v", v1.Expiration)
+	}
+	if *v1.GasData.Budget != 2000000 || *v1.GasData.Price != 1000 || len(*v1.GasData.Payment) != 1 {
+		t.Fatalf("unexpected gas data %+v", v1.GasData)
+	}
+	pt := v1.Kind.ProgrammableTransaction
+	if len(pt.Inputs) != 5 || len(pt.Commands) != 7 {
+		t.Fatalf("expected 5 inputs and 7 commands, got %d and %d", len(pt.Inputs), len(pt.Commands))
+	}
+	if shared := pt.Inputs[0].Object.SharedObject; shared == nil || shared.InitialSharedVersion != 12 || !shared.Mutable {
+		t.Fatalf("unexpected shared input %+v", pt.Inputs[0].Object)
+	}
+	if pt.Commands[0].SplitCoins == nil || pt.Commands[0].SplitCoins.Coin.GasCoin == nil {
+		t.Fatalf("expected split of the gas coin, got %+v", pt.Commands[0])
+	}
+	if vec := pt.Commands[2].MakeMoveVec; vec == nil || vec.Type == nil || vec.Type.String() != "0x2::coin::Coin<0x2::mgo::MGO>" {
+		t.Fatalf("unexpected make move vec %+v", pt.Commands[2])
+	}
+	moveCall := pt.Commands[3].MoveCall
+	if moveCall == nil || moveCall.Function != "deposit" || len(moveCall.TypeArguments) != 2 || moveCall.TypeArguments[1].String() != "vector<u8>" {
+		t.Fatalf("unexpected move call %+v", pt.Commands[3])
+	}
+	if publish := pt.Commands[4].Publish; publish == nil || !bytes.Equal(publish.Modules[1], []byte{1, 2, 3}) {
+		t.Fatalf("unexpected publish %+v", pt.Commands[4])
+	}
+
+	// a transaction without expiration decodes to the None variant
+	tx = newParseTestTransaction(t).SetExpiration(transaction.TransactionExpiration{None: struct{}{}})
+	req, err = tx.ToMgoExecuteTransactionBlockRequest(ctx, request.MgoTransactionBlockOptions{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = transaction.ParseTransactionData(req.TxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.V1.Expiration == nil || data.V1.Expiration.None == nil {
+		t.Fatalf("expected no expiration, got %+v", data.V1.Expiration)
+	}
+}
+
+func TestParseTransactionKind(t *testing.T) {
+	tx := newParseTestTransaction(t)
+	kindBytes, err := tx.Data.V1.Kind.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kind, err := transaction.ParseTransactionKind(bcs.ToBase64(kindBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := kind.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, kindBytes) {
+		t.Fatalf("transaction kind did not round trip")
+	}
+
+	if _, err := transaction.ParseTransactionKind(bcs.ToBase64(append(kindBytes, 0))); err == nil {
+		t.Fatal("expected trailing bytes to be rejected")
+	}
+	if _, err := transaction.ParseTransactionKind(bcs.ToBase64([]byte{2})); !errors.Is(err, transaction.ErrUnsupportedTransactionKind) {
+		t.Fatalf("expected genesis transactions to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package ptb_build
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction"
+)
+
+func TestTransactionPreview(t *testing.T) {
+	tx := newParseTestTransaction(t)
+	req, err := tx.ToMgoExecuteTransactionBlockRequest(ctx, request.MgoTransactionBlockOptions{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := transaction.PreviewTransaction(req.TxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Sender != keypair.SignerMgoAddress(tx.Signer) || preview.GasOwner != keypair.SignerMgoAddress(tx.Signer) {
+		t.Fatalf("unexpected sender %s and gas owner %s", preview.Sender, preview.GasOwner)
+	}
+	if preview.GasBudget != 2000000 || preview.ExpirationEpoch == nil || *preview.ExpirationEpoch != 0 {
+		t.Fatalf("unexpected gas budget %d and expiration %v", preview.GasBudget, preview.ExpirationEpoch)
+	}
+	if len(preview.MoveCalls) != 1 || preview.MoveCalls[0].String() != testPackage+"::pool::deposit<0x2::mgo::MGO, vector<u8>>" {
+		t.Fatalf("unexpected move calls %+v", preview.MoveCalls)
+	}
+	if len(preview.SplitCoins) != 1 || preview.SplitCoins[0].Coin != "GasCoin" ||
+		*preview.SplitCoins[0].Amounts[0] != 100 || *preview.SplitCoins[0].Amounts[1] != 200 {
+		t.Fatalf("unexpected split coins %+v", preview.SplitCoins)
+	}
+	if len(preview.Transfers) != 1 || preview.Transfers[0].Recipient != testObjectId(9) {
+		t.Fatalf("unexpected transfers %+v", preview.Transfers)
+	}
+	if preview.Publishes != 1 || len(preview.Upgrades) != 1 {
+		t.Fatalf("expected a publish and an upgrade, got %d and %v", preview.Publishes, preview.Upgrades)
+	}
+	if !strings.Contains(preview.String(), "SplitCoins GasCoin into [100, 200]") {
+		t.Fatalf("unexpected preview text:\n%s", preview)
+	}
+
+	txn := &model.TxnMetaData{TxBytes: req.TxBytes}
+	policies := []transaction.Policy{
+		transaction.AllowPackages("0x2"),
+		transaction.MaxGasBudget(1000000),
+		transaction.ForbidPublish(),
+	}
+	for _, policy := range policies {
+		if _, err := transaction.SignTransactionBlockWithPolicy(ctx, tx.Signer, txn, policy); !errors.Is(err, transaction.ErrPolicyViolation) {
+			t.Fatalf("expected policy violation, got %v", err)
+		}
+	}
+
+	allowed := transaction.Policies(transaction.AllowPackages(testPackage), transaction.MaxGasBudget(2000000))
+	signed, err := transaction.SignTransactionBlockWithPolicy(ctx, tx.Signer, txn, allowed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.Signature != req.Signature[0] {
+		t.Fatalf("expected the same signature as the transaction, got %s", signed.Signature)
+	}
+}
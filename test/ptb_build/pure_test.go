@@ -0,0 +1,154 @@
+package ptb_build
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction"
+)
+
+func newPureTestTransaction(t *testing.T, parameters []any) *transaction.Transaction {
+	node := testutil.NewNode(t)
+	cli := node.MgoClient()
+	node.Result("mgo_getNormalizedMoveFunction", map[string]any{"parameters": parameters})
+
+	return transaction.NewTransaction().
+		SetMgoClient(cli).
+		SetSigner(testutil.NewKeypair(t, config.Ed25519Flag)).
+		SetGasPrice(1000).
+		SetGasBudget(1000000).
+		SetGasPayment([]transaction.MgoObjectRef{})
+}
+
+func TestResolveUnresolvedPure(t *testing.T) {
+	tx := newPureTestTransaction(t, []any{
+		"U8",
+		"U128",
+		"Bool",
+		"Address",
+		map[string]any{"Vector": "U16"},
+		map[string]any{"Vector": "U8"},
+		structType("0x1", "string", "String"),
+		map[string]any{"Struct": map[string]any{
+			"address":       "0x1",
+			"module":        "option",
+			"name":          "Option",
+			"typeArguments": []any{"U64"},
+		}},
+		structType("0x2", "object", "ID"),
+		map[string]any{"TypeParameter": 0.0},
+		map[string]any{"MutableReference": structType("0x2", "tx_context", "TxContext")},
+	})
+	isU32 := true
+	tx.MoveCall(
+		model.MgoAddress(testPackage),
+		"pure",
+		"accept",
+		[]transaction.TypeTag{{U32: &isU32}},
+		[]transaction.Argument{
+			tx.UnresolvedPure(7),
+			tx.UnresolvedPure("340282366920938463463374607431768211455"),
+			tx.UnresolvedPure(true),
+			tx.UnresolvedPure("0x2"),
+			tx.UnresolvedPure([]uint16{1, 256}),
+			tx.UnresolvedPure("hello"),
+			tx.UnresolvedPure("hello"),
+			tx.UnresolvedPure(nil),
+			tx.UnresolvedPure(testObjectId(3)),
+			tx.UnresolvedPure(uint32(5)),
+		},
+	)
+	coins := tx.SplitCoins(tx.Gas(), []transaction.Argument{tx.UnresolvedPure(1000)})
+	tx.TransferObjects([]transaction.Argument{coins}, tx.UnresolvedPure(testObjectId(9)))
+
+	if _, err := tx.ToMgoExecuteTransactionBlockRequest(ctx, request.MgoTransactionBlockOptions{}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	address := func(b byte) []byte {
+		return append(bytes.Repeat([]byte{0}, 31), b)
+	}
+	expected := [][]byte{
+		{7},
+		bytes.Repeat([]byte{0xff}, 16),
+		{1},
+		address(2),
+		{2, 1, 0, 0, 1},
+		append([]byte{5}, "hello"...),
+		append([]byte{5}, "hello"...),
+		{0},
+		address(3),
+		{5, 0, 0, 0},
+		{0xe8, 0x03, 0, 0, 0, 0, 0, 0},
+		address(9),
+	}
+	inputs := tx.Data.V1.Kind.ProgrammableTransaction.Inputs
+	if len(inputs) != len(expected) {
+		t.Fatalf("expected %d inputs, got %d", len(expected), len(inputs))
+	}
+	for i, input := range inputs {
+		if input.UnresolvedPure != nil || input.Pure == nil {
+			t.Fatalf("input %d was not resolved", i)
+		}
+		if !bytes.Equal(input.Pure.Bytes, expected[i]) {
+			t.Fatalf("input %d: expected %x, got %x", i, expected[i], input.Pure.Bytes)
+		}
+	}
+}
+
+func TestResolveUnresolvedPureMismatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		parameter any
+		value     any
+		expected  string
+	}{
+		{name: "string for address", parameter: "Address", value: "hello", expected: "argument 0 of pure::accept"},
+		{name: "overflow", parameter: "U8", value: 256, expected: "argument 0 of pure::accept: 256 out of range for U8"},
+		{name: "negative", parameter: "U64", value: -1, expected: "argument 0 of pure::accept: -1 out of range for U64"},
+		{name: "bool for integer", parameter: "U64", value: true, expected: "argument 0 of pure::accept: expected integer"},
+		{name: "struct", parameter: structType(testPackage, "pool", "Pool"), value: 1, expected: "cannot be passed as a pure value"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := newPureTestTransaction(t, []any{test.parameter})
+			tx.MoveCall(model.MgoAddress(testPackage), "pure", "accept", nil, []transaction.Argument{tx.UnresolvedPure(test.value)})
+
+			_, err := tx.ToMgoExecuteTransactionBlockRequest(ctx, request.MgoTransactionBlockOptions{}, "")
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Fatalf("expected error containing %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestPureStrings(t *testing.T) {
+	tx := transaction.NewTransaction()
+	values := []string{"0x2", testObjectId(3), "100", "cafe", "hello", "0x", "0xgg"}
+	for _, value := range values {
+		tx.Pure(value)
+	}
+
+	address := func(b byte) []byte {
+		return append(bytes.Repeat([]byte{0}, 31), b)
+	}
+	expected := [][]byte{
+		address(2),
+		address(3),
+		append([]byte{3}, "100"...),
+		append([]byte{4}, "cafe"...),
+		append([]byte{5}, "hello"...),
+		append([]byte{2}, "0x"...),
+		append([]byte{4}, "0xgg"...),
+	}
+	for i, input := range tx.Data.V1.Kind.ProgrammableTransaction.Inputs {
+		if !bytes.Equal(input.Pure.Bytes, expected[i]) {
+			t.Fatalf("%s: expected %x, got %x", values[i], expected[i], input.Pure.Bytes)
+		}
+	}
+}
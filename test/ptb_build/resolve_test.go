@@ -0,0 +1,111 @@
+package ptb_build
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction"
+)
+
+const testPackage = "0x00000000000000000000000000000000000000000000000000000000000000ab"
+
+func structType(address, module, name string) map[string]any {
+	return map[string]any{"Struct": map[string]any{
+		"address":       address,
+		"module":        module,
+		"name":          name,
+		"typeArguments": []any{},
+	}}
+}
+
+func TestResolveUnresolvedObjects(t *testing.T) {
+	node := testutil.NewNode(t)
+	cli := node.MgoClient()
+	node.Result("mgo_getNormalizedMoveFunction", map[string]any{
+		"parameters": []any{
+			map[string]any{"MutableReference": structType(testPackage, "pool", "Pool")},
+			map[string]any{"Reference": structType("0x2", "clock", "Clock")},
+			structType(testPackage, "pool", "Ticket"),
+			structType("0x2", "transfer", "Receiving"),
+			map[string]any{"MutableReference": structType("0x2", "tx_context", "TxContext")},
+		},
+	})
+	node.Handle("mgo_multiGetObjects", func(req *testutil.Request) any {
+		var ids []string
+		if err := json.Unmarshal(req.Params[0], &ids); err != nil {
+			t.Fatal(err)
+		}
+		owners := map[string]any{
+			testObjectId(1): map[string]any{"Shared": map[string]any{"initial_shared_version": 11}},
+			testObjectId(6): map[string]any{"Shared": map[string]any{"initial_shared_version": 1}},
+			testObjectId(3): map[string]any{"AddressOwner": testObjectId(9)},
+			testObjectId(4): map[string]any{"ObjectOwner": testObjectId(1)},
+		}
+		objects := make([]any, len(ids))
+		for i, id := range ids {
+			objects[i] = map[string]any{"data": map[string]any{
+				"objectId": id,
+				"version":  "5",
+				"digest":   testDigest(byte(i + 1)),
+				"owner":    owners[id],
+			}}
+		}
+		return objects
+	})
+
+	signer := testutil.NewKeypair(t, config.Ed25519Flag)
+	tx := transaction.NewTransaction().
+		SetMgoClient(cli).
+		SetSigner(signer).
+		SetGasPrice(1000).
+		SetGasBudget(1000000).
+		SetGasPayment([]transaction.MgoObjectRef{})
+	tx.MoveCall(
+		model.MgoAddress(testPackage),
+		"pool",
+		"swap",
+		nil,
+		[]transaction.Argument{
+			tx.Object(testObjectId(1)),
+			tx.Object("0x6"),
+			tx.Object(testObjectId(3)),
+			tx.Object(testObjectId(4)),
+		},
+	)
+	if again := tx.Object(testObjectId(1)); *again.Input != 0 {
+		t.Fatalf("expected the same object to reuse input 0, got %d", *again.Input)
+	}
+
+	if _, err := tx.ToMgoExecuteTransactionBlockRequest(ctx, request.MgoTransactionBlockOptions{}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := tx.Data.V1.Kind.ProgrammableTransaction.Inputs
+	if len(inputs) != 4 {
+		t.Fatalf("expected 4 inputs, got %d", len(inputs))
+	}
+	for i, input := range inputs {
+		if input.UnresolvedObject != nil || input.Object == nil {
+			t.Fatalf("input %d was not resolved", i)
+		}
+	}
+	if shared := inputs[0].Object.SharedObject; shared == nil || shared.InitialSharedVersion != 11 || !shared.Mutable {
+		t.Fatalf("expected mutable shared object with version 11, got %+v", inputs[0].Object)
+	}
+	if shared := inputs[1].Object.SharedObject; shared == nil || shared.InitialSharedVersion != 1 || shared.Mutable {
+		t.Fatalf("expected immutable shared clock, got %+v", inputs[1].Object)
+	}
+	if owned := inputs[2].Object.ImmOrOwnedObject; owned == nil || owned.Version != 5 {
+		t.Fatalf("expected owned object, got %+v", inputs[2].Object)
+	}
+	if inputs[3].Object.Receiving == nil {
+		t.Fatalf("expected receiving object, got %+v", inputs[3].Object)
+	}
+	if node.CallCount("mgo_getNormalizedMoveFunction") != 1 {
+		t.Fatalf("expected a single function lookup, got %d", node.CallCount("mgo_getNormalizedMoveFunction"))
+	}
+}
@@ -0,0 +1,162 @@
+package ptb_build
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/test/testutil"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction/gasstation"
+)
+
+func buildKind(t *testing.T, pkg string, useGasCoin bool) string {
+	tx := transaction.NewTransaction()
+	args := []transaction.Argument{tx.Pure(uint64(5))}
+	if useGasCoin {
+		args = append(args, tx.Gas())
+	}
+	tx.MoveCall(model.MgoAddress(pkg), "pool", "deposit", nil, args)
+	kind, err := tx.BuildKind(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kind
+}
+
+func postSponsor(t *testing.T, url, sender, kind string) (*http.Response, *transaction.SponsoredTransaction) {
+	body, _ := json.Marshal(gasstation.SponsorRequest{Sender: sender, TxKindBytes: kind})
+	rsp, err := http.Post(url+"/sponsor", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return rsp, nil
+	}
+	var sponsored transaction.SponsoredTransaction
+	if err := json.NewDecoder(rsp.Body).Decode(&sponsored); err != nil {
+		t.Fatal(err)
+	}
+	return rsp, &sponsored
+}
+
+func TestSponsoredTransaction(t *testing.T) {
+	node := testutil.NewNode(t)
+	cli := node.MgoClient()
+	node.Result("mgox_getReferenceGasPrice", "1000")
+	node.Result("mgo_getProtocolConfig", map[string]any{"attributes": map[string]any{}})
+	node.Result("mgox_getCoins", map[string]any{
+		"data":        []any{coin(1, "50000000", 0)},
+		"hasNextPage": false,
+	})
+
+	sponsorKey, sender, otherSender := testutil.NewKeypair(t, config.Ed25519Flag), testutil.NewKeypair(t, config.Ed25519Flag), testutil.NewKeypair(t, config.Ed25519Flag)
+	sponsor := transaction.NewSponsor(cli, sponsorKey).SetGasBudget(1000000)
+	station := httptest.NewServer(gasstation.NewHandler(gasstation.Config{
+		Sponsor:           sponsor,
+		AllowedPackages:   []string{testPackage},
+		MaxSpendPerSender: 1500000,
+	}))
+	defer station.Close()
+
+	kind := buildKind(t, testPackage, false)
+	rsp, sponsored := postSponsor(t, station.URL, sender.MgoAddress(), kind)
+	if sponsored == nil {
+		t.Fatalf("expected the transaction to be sponsored, got %s", rsp.Status)
+	}
+	preview, err := transaction.PreviewTransaction(sponsored.TxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Sender != sender.MgoAddress() || preview.GasOwner != string(sponsor.MgoAddress()) || len(preview.GasPayment) != 1 {
+		t.Fatalf("unexpected sponsored transaction %s", preview)
+	}
+	if digest, _ := transaction.TransactionDigestFromBase64(sponsored.TxBytes); digest != sponsored.Digest {
+		t.Fatalf("expected digest %s, got %s", digest, sponsored.Digest)
+	}
+
+	if _, err := sponsored.Sign(ctx, otherSender, kind); !errors.Is(err, transaction.ErrSponsoredMismatch) {
+		t.Fatalf("expected signing as another sender to fail, got %v", err)
+	}
+	if _, err := sponsored.Sign(ctx, sender, buildKind(t, "0x2", false)); !errors.Is(err, transaction.ErrSponsoredMismatch) {
+		t.Fatalf("expected signing another transaction kind to fail, got %v", err)
+	}
+	signature, err := sponsored.Sign(ctx, sender, kind)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := sponsored.ToMgoExecuteTransactionBlockRequest(signature, request.MgoTransactionBlockOptions{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Signature) != 2 || req.Signature[0] != sponsored.SponsorSignature || req.Signature[1] != signature {
+		t.Fatalf("unexpected signatures %v", req.Signature)
+	}
+
+	if rsp, _ := postSponsor(t, station.URL, sender.MgoAddress(), kind); rsp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected the spend limit of the sender to be exceeded, got %s", rsp.Status)
+	}
+	if rsp, _ := postSponsor(t, station.URL, otherSender.MgoAddress(), buildKind(t, "0x2", false)); rsp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected a package outside the allow-list to be rejected, got %s", rsp.Status)
+	}
+	if rsp, _ := postSponsor(t, station.URL, otherSender.MgoAddress(), buildKind(t, testPackage, true)); rsp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected a transaction using the gas coin to be rejected, got %s", rsp.Status)
+	}
+	if rsp, sponsored := postSponsor(t, station.URL, otherSender.MgoAddress(), kind); sponsored == nil {
+		t.Fatalf("expected rejected transactions not to count towards the spend limit, got %s", rsp.Status)
+	}
+}
+
+func TestGasStationErrors(t *testing.T) {
+	node := testutil.NewNode(t)
+	cli := node.MgoClient()
+	node.Result("mgox_getReferenceGasPrice", "1000")
+	node.Result("mgo_getProtocolConfig", map[string]any{"attributes": map[string]any{}})
+	node.Result("mgox_getCoins", map[string]any{"data": []any{}, "hasNextPage": false})
+
+	sponsor := transaction.NewSponsor(cli, testutil.NewKeypair(t, config.Ed25519Flag)).SetGasBudget(1000000)
+	station := httptest.NewServer(gasstation.NewHandler(gasstation.Config{
+		Sponsor:         sponsor,
+		AllowedPackages: []string{testPackage},
+	}))
+	defer station.Close()
+	sender := testutil.NewKeypair(t, config.Ed25519Flag).MgoAddress()
+
+	post := func(body string) (int, string) {
+		rsp, err := http.Post(station.URL+"/sponsor", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rsp.Body.Close()
+		b, _ := io.ReadAll(rsp.Body)
+		return rsp.StatusCode, strings.TrimSpace(string(b))
+	}
+
+	for _, tc := range []struct {
+		name   string
+		body   string
+		status int
+		text   string
+	}{
+		{"malformed json", `{"sender":`, http.StatusBadRequest, "invalid request body"},
+		{"missing sender", `{"txKindBytes":"AA=="}`, http.StatusBadRequest, "sender and txKindBytes are required"},
+		{"invalid base64", `{"sender":"` + sender + `","txKindBytes":"not base64"}`, http.StatusBadRequest, "invalid txKindBytes"},
+		{"truncated kind", `{"sender":"` + sender + `","txKindBytes":"AAE="}`, http.StatusBadRequest, "invalid txKindBytes"},
+		{"system kind", `{"sender":"` + sender + `","txKindBytes":"AQ=="}`, http.StatusBadRequest, "invalid txKindBytes"},
+		// The sponsor has no gas coins: the internal error is not returned to the client.
+		{"sponsor failure", `{"sender":"` + sender + `","txKindBytes":"` + buildKind(t, testPackage, false) + `"}`, http.StatusBadGateway, "failed to sponsor transaction"},
+	} {
+		if status, text := post(tc.body); status != tc.status || text != tc.text {
+			t.Errorf("%s: expected %d %q, got %d %q", tc.name, tc.status, tc.text, status, text)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package ptb_build
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction"
+)
+
+func TestTypeTagRoundTrip(t *testing.T) {
+	types := []string{
+		"u8",
+		"u256",
+		"bool",
+		"address",
+		"vector<u8>",
+		"vector<vector<u64>>",
+		"0x2::mgo::MGO",
+		"0x2::coin::Coin<0x2::mgo::MGO>",
+		"0x2::dynamic_field::Field<0x1::string::String, vector<0x2::coin::Coin<0x2::mgo::MGO>>>",
+		"0x5d4b302506645c37ff133b98c4b50a5ae14841659738d6d733d59d0d217a93bf::coin::COIN",
+	}
+	for _, typeString := range types {
+		tag, err := transaction.ParseTypeTag(typeString)
+		if err != nil {
+			t.Fatalf("%s: %v", typeString, err)
+		}
+		if tag.String() != typeString {
+			t.Fatalf("expected %s, got %s", typeString, tag.String())
+		}
+
+		encoded, err := bcs.Marshal(tag)
+		if err != nil {
+			t.Fatalf("%s: %v", typeString, err)
+		}
+		var decoded transaction.TypeTag
+		n, err := bcs.Unmarshal(encoded, &decoded)
+		if err != nil {
+			t.Fatalf("%s: %v", typeString, err)
+		}
+		if n != len(encoded) || decoded.String() != typeString {
+			t.Fatalf("expected %s after bcs round trip, got %s", typeString, decoded.String())
+		}
+	}
+}
+
+func TestTypeTagForms(t *testing.T) {
+	tag, err := transaction.ParseStructTag("0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin< 0x2::mgo::MGO >")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.String() != "0x2::coin::Coin<0x2::mgo::MGO>" {
+		t.Fatalf("unexpected short form %s", tag.String())
+	}
+	canonical := "0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x0000000000000000000000000000000000000000000000000000000000000002::mgo::MGO>"
+	if tag.CanonicalString() != canonical {
+		t.Fatalf("unexpected canonical form %s", tag.CanonicalString())
+	}
+
+	u64, err := transaction.ParseTypeTag("u64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := bcs.Marshal(u64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, []byte{2}) {
+		t.Fatalf("expected u64 to encode as a single variant byte, got %x", encoded)
+	}
+
+	for _, invalid := range []string{"", "u64>", "vector<u8", "0x2::coin", "0xzz::coin::Coin", "0x2::coin::Coin<>", "0x2::coin::Coin<u8,"} {
+		if _, err := transaction.ParseTypeTag(invalid); !errors.Is(err, transaction.ErrInvalidTypeTag) {
+			t.Fatalf("expected %q to be rejected, got %v", invalid, err)
+		}
+	}
+	if _, err := transaction.ParseStructTag("vector<u8>"); !errors.Is(err, transaction.ErrInvalidTypeTag) {
+		t.Fatalf("expected vector to be rejected as a struct tag, got %v", err)
+	}
+}
+
+func TestMakeMoveVecInvalidType(t *testing.T) {
+	tx := transaction.NewTransaction()
+	invalid := "vector<"
+	tx.MakeMoveVec(&invalid, []transaction.Argument{tx.Pure(uint64(1))})
+	if _, err := tx.BuildKind(ctx); !errors.Is(err, transaction.ErrInvalidTypeTag) {
+		t.Fatalf("expected an invalid type tag when building, got %v", err)
+	}
+}
@@ -2,11 +2,15 @@ package read
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
 	"github.com/mangonet-labs/mgo-go-sdk/client"
 	"github.com/mangonet-labs/mgo-go-sdk/config"
+	"github.com/mangonet-labs/mgo-go-sdk/model/filter"
 	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction"
 	"github.com/mangonet-labs/mgo-go-sdk/utils"
 )
 
@@ -167,3 +171,43 @@ func TestTryMultiGetPastObjects(t *testing.T) {
 	}
 	utils.JsonPrint(object)
 }
+
+// TestTransactionDigestMatchesNode checks the offline transaction digest against the
+// digest the node assigned to a recent programmable transaction.
+func TestTransactionDigestMatchesNode(t *testing.T) {
+	rsp, err := devCli.MgoXQueryTransactionBlocks(ctx, request.MgoXQueryTransactionBlocksRequest{
+		MgoTransactionBlockResponseQuery: request.MgoTransactionBlockResponseQuery{
+			TransactionFilter: filter.TransactionKind("ProgrammableTransaction"),
+			Options:           request.MgoTransactionBlockOptions{ShowRawInput: true},
+		},
+		Limit:           1,
+		DescendingOrder: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rsp.Data) == 0 {
+		t.Fatal("expected a transaction")
+	}
+	raw, err := base64.StdEncoding.DecodeString(rsp.Data[0].RawTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The raw transaction is the BCS encoded sender signed data: a vector of one
+	// transaction, its 3 bytes intent, the transaction data and the signatures.
+	if len(raw) < 4 || raw[0] != 1 {
+		t.Fatalf("unexpected raw transaction %x", raw)
+	}
+	var data transaction.TransactionData
+	if _, err := bcs.Unmarshal(raw[4:], &data); err != nil {
+		t.Fatal(err)
+	}
+	digest, err := data.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(digest) != rsp.Data[0].Digest {
+		t.Fatalf("expected digest %s, got %s", rsp.Data[0].Digest, digest)
+	}
+}
@@ -0,0 +1,17 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/config"
+)
+
+// NewKeypair returns a random keypair of scheme.
+func NewKeypair(t testing.TB, scheme config.Scheme) *keypair.Keypair {
+	kp, err := keypair.NewKeypair(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kp
+}
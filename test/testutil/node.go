@@ -0,0 +1,156 @@
+// Package testutil holds the fixtures shared by the tests of the SDK.
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+)
+
+const methodNotFound = -32601
+
+// Request is a JSON-RPC request received by a Node.
+type Request struct {
+	ID     int64             `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	// Header is the header of the HTTP request carrying the JSON-RPC request.
+	Header http.Header `json:"-"`
+}
+
+// RPCError is returned by a Handler to answer with a JSON-RPC error.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// HTTPResponse is returned by a Handler to answer the HTTP request with a raw status and
+// body instead of a JSON-RPC response, e.g. to simulate a gateway error.
+type HTTPResponse struct {
+	StatusCode int
+	Body       string
+}
+
+// Handler answers a request with its result, an *RPCError or an *HTTPResponse.
+type Handler func(req *Request) any
+
+// Node is a JSON-RPC stand-in for a full node that answers each method with a handler.
+// Methods without a handler are answered with a method not found error. Batch requests
+// are answered in order.
+type Node struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]Handler
+	requests []*Request
+}
+
+// NewNode starts a node that is closed at the end of the test.
+func NewNode(t testing.TB) *Node {
+	n := &Node{handlers: map[string]Handler{}}
+	n.Server = httptest.NewServer(http.HandlerFunc(n.serveHTTP))
+	t.Cleanup(n.Close)
+	return n
+}
+
+// MgoClient returns a client of the node.
+func (n *Node) MgoClient() *client.Client {
+	return client.NewMgoClient(n.URL)
+}
+
+// Handle answers the requests of method with handler.
+func (n *Node) Handle(method string, handler Handler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.handlers[method] = handler
+}
+
+// Result answers the requests of method with result.
+func (n *Node) Result(method string, result any) {
+	n.Handle(method, func(*Request) any { return result })
+}
+
+// CallCount returns the number of requests of method received so far.
+func (n *Node) CallCount(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	count := 0
+	for _, req := range n.requests {
+		if req.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+// Requests returns the requests received so far.
+func (n *Node) Requests() []*Request {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*Request(nil), n.requests...)
+}
+
+func (n *Node) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var reqs []*Request
+	batch := bytes.HasPrefix(bytes.TrimSpace(body), []byte("["))
+	if batch {
+		err = json.Unmarshal(body, &reqs)
+	} else {
+		reqs = []*Request{{}}
+		err = json.Unmarshal(body, reqs[0])
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rsps := make([]map[string]any, 0, len(reqs))
+	for _, req := range reqs {
+		req.Header = r.Header.Clone()
+		rsp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		switch result := n.answer(req).(type) {
+		case *HTTPResponse:
+			if result.StatusCode != 0 {
+				w.WriteHeader(result.StatusCode)
+			}
+			_, _ = w.Write([]byte(result.Body))
+			return
+		case *RPCError:
+			rsp["error"] = result
+		default:
+			rsp["result"] = result
+		}
+		rsps = append(rsps, rsp)
+	}
+
+	if batch {
+		_ = json.NewEncoder(w).Encode(rsps)
+	} else {
+		_ = json.NewEncoder(w).Encode(rsps[0])
+	}
+}
+
+func (n *Node) answer(req *Request) any {
+	n.mu.Lock()
+	n.requests = append(n.requests, req)
+	handler, ok := n.handlers[req.Method]
+	n.mu.Unlock()
+
+	if !ok {
+		return &RPCError{Code: methodNotFound, Message: "method not found: " + req.Method}
+	}
+	return handler(req)
+}
@@ -2,4 +2,13 @@ package transaction
 
 const (
 	defaultGasBudget = 50000000
+
+	// mgoCoinType is the coin type used to pay for gas.
+	mgoCoinType = "0x2::mgo::MGO"
+	// gasCoinPageLimit is the page size used when listing gas coins of the gas owner.
+	gasCoinPageLimit = 50
+	// defaultMaxGasPaymentObjects is used when the node does not report `max_gas_payment_objects`.
+	defaultMaxGasPaymentObjects = 256
+	// defaultMaxTxGas is used as the dry run gas budget when the node does not report `max_tx_gas`.
+	defaultMaxTxGas = 50000000000
 )
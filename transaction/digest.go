@@ -0,0 +1,68 @@
+package transaction
+
+import (
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/utils"
+	"github.com/mr-tron/base58"
+)
+
+// The prefixes hashed with the BCS bytes of a value to get its digest.
+const (
+	transactionDataDigestPrefix    = "TransactionData::"
+	transactionEffectsDigestPrefix = "TransactionEffects::"
+	objectDigestPrefix             = "Object::"
+)
+
+// Digest returns the digest of the transaction data, the transaction digest assigned by
+// the node, so it can be recorded before the transaction is submitted.
+func (td *TransactionData) Digest() (model.TransactionDigest, error) {
+	txBytes, err := td.Marshal()
+	if err != nil {
+		return "", err
+	}
+
+	return TransactionDigestFromBytes(txBytes), nil
+}
+
+// Digest returns the digest of the built transaction. Call it after the transaction is
+// built, e.g. by ToMgoExecuteTransactionBlockRequest; unresolved inputs or gas data fail.
+func (tx *Transaction) Digest() (model.TransactionDigest, error) {
+	if _, err := tx.build(false); err != nil {
+		return "", err
+	}
+
+	return tx.Data.Digest()
+}
+
+// TransactionDigestFromBytes returns the digest of the BCS bytes of transaction data.
+func TransactionDigestFromBytes(txBytes []byte) model.TransactionDigest {
+	return model.TransactionDigest(digest(transactionDataDigestPrefix, txBytes))
+}
+
+// TransactionDigestFromBase64 returns the digest of base64 encoded transaction bytes,
+// such as the TxBytes of an execute request.
+func TransactionDigestFromBase64(b64TxBytes string) (model.TransactionDigest, error) {
+	txBytes, err := bcs.FromBase64(b64TxBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return TransactionDigestFromBytes(txBytes), nil
+}
+
+// TransactionEffectsDigest returns the digest of the BCS bytes of transaction effects,
+// such as the RawEffects of a response requested with ShowRawEffects.
+func TransactionEffectsDigest(effectsBytes []byte) string {
+	return digest(transactionEffectsDigestPrefix, effectsBytes)
+}
+
+// ObjectDigest returns the digest of the BCS bytes of an object, including its owner and
+// previous transaction; the bcsBytes of a Move object are only its contents.
+func ObjectDigest(objectBytes []byte) model.ObjectDigest {
+	return model.ObjectDigest(digest(objectDigestPrefix, objectBytes))
+}
+
+func digest(prefix string, data []byte) string {
+	return base58.Encode(utils.Keccak256(append([]byte(prefix), data...)))
+}
@@ -1,6 +1,9 @@
 package transaction
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrSignerNotSet         = errors.New("signer not set")
@@ -10,4 +13,20 @@ var (
 	ErrInvalidMgoAddress    = errors.New("invalid mgo address")
 	ErrInvalidObjectId      = errors.New("invalid object id")
 	ErrObjectNotSupportType = errors.New("object not support type")
+	ErrInsufficientGasCoins = errors.New("insufficient gas coins to cover the gas budget")
+	ErrGasPriceNotSet       = errors.New("gas price not set")
+	ErrUnresolvedPureType   = errors.New("unresolved pure value is not used by any command with a known type")
+
+	ErrUnsupportedTransactionKind = errors.New("unsupported transaction kind")
 )
+
+// DryRunError is returned by gas budget estimation when the dry run of the
+// transaction reports a failed execution status.
+type DryRunError struct {
+	Status  string
+	Message string
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run failed with status %s: %s", e.Status, e.Message)
+}
@@ -0,0 +1,257 @@
+package transaction
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/model/response"
+)
+
+type gasCoin struct {
+	ref     MgoObjectRef
+	balance uint64
+}
+
+// selectGasPayment pages through the MGO coins of the gas owner and sets the smallest
+// set of coins, largest first, that covers the gas budget as the gas payment.
+// Coins that are already used as transaction inputs and coins that are still locked
+// are skipped, and no more than the protocol's `max_gas_payment_objects` coins are used.
+func (tx *Transaction) selectGasPayment(ctx context.Context) error {
+	if tx.MgoClient == nil {
+		return ErrMgoClientNotSet
+	}
+
+	maxObjects, err := tx.maxGasPaymentObjects(ctx)
+	if err != nil {
+		return err
+	}
+
+	owner := ConvertMgoAddressBytesToString(*tx.Data.V1.GasData.Owner)
+	budget := *tx.Data.V1.GasData.Budget
+
+	var (
+		candidates   []gasCoin
+		currentEpoch *uint64
+		cursor       interface{}
+	)
+	for {
+		rsp, err := tx.MgoClient.MgoXGetCoins(ctx, request.MgoXGetCoinsRequest{
+			Owner:    string(owner),
+			CoinType: mgoCoinType,
+			Cursor:   cursor,
+			Limit:    gasCoinPageLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, coin := range rsp.Data {
+			if tx.Data.V1.GetInputObjectIndex(model.MgoAddress(coin.CoinObjectId)) != nil {
+				continue
+			}
+			if coin.LockedUntilEpoch > 0 {
+				if currentEpoch == nil {
+					epoch, err := tx.currentEpoch(ctx)
+					if err != nil {
+						return err
+					}
+					currentEpoch = &epoch
+				}
+				if coin.LockedUntilEpoch > *currentEpoch {
+					continue
+				}
+			}
+
+			candidate, err := newGasCoin(coin)
+			if err != nil {
+				return err
+			}
+			candidates = append(candidates, *candidate)
+		}
+
+		if payment := pickGasCoins(candidates, budget, maxObjects); payment != nil {
+			tx.SetGasPayment(payment)
+			return nil
+		}
+
+		if !rsp.HasNextPage || rsp.NextCursor == "" {
+			break
+		}
+		cursor = rsp.NextCursor
+	}
+
+	return ErrInsufficientGasCoins
+}
+
+// pickGasCoins returns the fewest coins, taken in descending balance order, whose total
+// balance covers the budget, or nil if the budget cannot be covered with at most maxObjects coins.
+func pickGasCoins(candidates []gasCoin, budget uint64, maxObjects int) []MgoObjectRef {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].balance > candidates[j].balance
+	})
+
+	var total uint64
+	var payment []MgoObjectRef
+	for _, candidate := range candidates {
+		if len(payment) >= maxObjects {
+			return nil
+		}
+		payment = append(payment, candidate.ref)
+		total += candidate.balance
+		if total >= budget {
+			return payment
+		}
+	}
+
+	return nil
+}
+
+func newGasCoin(coin response.CoinData) (*gasCoin, error) {
+	balance, err := strconv.ParseUint(coin.Balance, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := NewMgoObjectRef(model.MgoAddress(coin.CoinObjectId), coin.Version, model.ObjectDigest(coin.Digest))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gasCoin{
+		ref:     *ref,
+		balance: balance,
+	}, nil
+}
+
+// maxGasPaymentObjects reads `max_gas_payment_objects` from the node's protocol config.
+func (tx *Transaction) maxGasPaymentObjects(ctx context.Context) (int, error) {
+	rsp, err := tx.MgoClient.MgoGetProtocolConfig(ctx, request.MgoGetProtocolConfigRequest{})
+	if err != nil {
+		return 0, err
+	}
+	value, ok := protocolConfigUint(rsp, "max_gas_payment_objects")
+	if !ok || value == 0 {
+		return defaultMaxGasPaymentObjects, nil
+	}
+
+	return int(value), nil
+}
+
+func (tx *Transaction) currentEpoch(ctx context.Context) (uint64, error) {
+	rsp, err := tx.MgoClient.MgoXGetLatestMgoSystemState(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(rsp.Epoch, 10, 64)
+}
+
+// protocolConfigUint returns a numeric protocol config attribute. Attributes are reported
+// by the node as a single-entry map keyed by their type, e.g. {"u32": "256"}.
+func protocolConfigUint(config response.ProtocolConfigResponse, name string) (uint64, bool) {
+	for _, value := range config.Attributes[name] {
+		v, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return v, true
+	}
+
+	return 0, false
+}
+
+// EstimateGasBudget dry runs the transaction and returns a gas budget computed from the
+// reported gas cost summary: computation + storage - rebate, never less than the
+// computation cost, increased by safetyMarginPercent. The dry run uses the protocol's
+// `max_tx_gas` as budget and does not modify the transaction.
+func (tx *Transaction) EstimateGasBudget(ctx context.Context, safetyMarginPercent uint64) (uint64, error) {
+	if tx.MgoClient == nil {
+		return 0, ErrMgoClientNotSet
+	}
+	v1 := tx.Data.V1
+	if v1.Sender == nil {
+		return 0, ErrSenderNotSet
+	}
+	if v1.GasData.Price == nil {
+		return 0, ErrGasPriceNotSet
+	}
+
+	protocolConfig, err := tx.MgoClient.MgoGetProtocolConfig(ctx, request.MgoGetProtocolConfigRequest{})
+	if err != nil {
+		return 0, err
+	}
+	maxTxGas, ok := protocolConfigUint(protocolConfig, "max_tx_gas")
+	if !ok || maxTxGas == 0 {
+		maxTxGas = defaultMaxTxGas
+	}
+
+	payment := v1.GasData.Payment
+	if payment == nil {
+		payment = &[]MgoObjectRef{}
+	}
+	owner := v1.GasData.Owner
+	if owner == nil {
+		owner = v1.Sender
+	}
+	expiration := v1.Expiration
+	if expiration == nil {
+		expiration = &TransactionExpiration{None: struct{}{}}
+	}
+	dryRunData := TransactionData{
+		V1: &TransactionDataV1{
+			Kind:   v1.Kind,
+			Sender: v1.Sender,
+			GasData: &GasData{
+				Payment: payment,
+				Owner:   owner,
+				Price:   v1.GasData.Price,
+				Budget:  &maxTxGas,
+			},
+			Expiration: expiration,
+		},
+	}
+	bcsEncodedMsg, err := dryRunData.Marshal()
+	if err != nil {
+		return 0, err
+	}
+
+	rsp, err := tx.MgoClient.MgoDryRunTransactionBlock(ctx, request.MgoDryRunTransactionBlockRequest{
+		TxBytes: bcs.ToBase64(bcsEncodedMsg),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if rsp.Effects.Status.Status != "success" {
+		return 0, &DryRunError{
+			Status:  rsp.Effects.Status.Status,
+			Message: rsp.Effects.Status.Error,
+		}
+	}
+
+	return gasBudgetFromSummary(rsp.Effects.GasUsed, safetyMarginPercent)
+}
+
+func gasBudgetFromSummary(summary model.GasCostSummary, safetyMarginPercent uint64) (uint64, error) {
+	computationCost, err := strconv.ParseUint(summary.ComputationCost, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	storageCost, err := strconv.ParseUint(summary.StorageCost, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	storageRebate, err := strconv.ParseUint(summary.StorageRebate, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	budget := computationCost
+	if storageCost > storageRebate {
+		budget += storageCost - storageRebate
+	}
+
+	return budget + budget*safetyMarginPercent/100, nil
+}
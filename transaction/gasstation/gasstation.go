@@ -0,0 +1,217 @@
+// Package gasstation serves a transaction.Sponsor over HTTP, so an app can pay the gas
+// of the transactions its users build.
+//
+// The protocol has one JSON endpoint:
+//
+//	POST /sponsor  {"sender": "0x...", "txKindBytes": "<base64>"} -> transaction.SponsoredTransaction
+//
+// The user builds the transaction kind with Transaction.BuildKind, posts it, signs the
+// returned transaction with SponsoredTransaction.Sign and executes it with both
+// signatures.
+package gasstation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/transaction"
+	"github.com/mangonet-labs/mgo-go-sdk/utils"
+)
+
+const (
+	sponsorPath   = "/sponsor"
+	defaultWindow = 24 * time.Hour
+	maxBodySize   = 1 << 20
+)
+
+var ErrSpendLimit = errors.New("gas spend limit exceeded")
+
+type SponsorRequest struct {
+	Sender      string `json:"sender"`
+	TxKindBytes string `json:"txKindBytes"`
+}
+
+// Config configures a gas station.
+type Config struct {
+	Sponsor *transaction.Sponsor
+	// AllowedPackages are the packages sponsored transactions may call. Transactions
+	// calling any other package are rejected, as are transactions publishing or
+	// upgrading packages.
+	AllowedPackages []string
+	// MaxGasBudget is the highest gas budget of a sponsored transaction. Zero is no limit.
+	MaxGasBudget uint64
+	// MaxSpendPerSender is the total gas budget sponsored for a sender within Window.
+	// Zero is no limit.
+	MaxSpendPerSender uint64
+	// MaxSpend is the total gas budget sponsored within Window. Zero is no limit.
+	MaxSpend uint64
+	// Window is the period of the spend limits, 24 hours by default.
+	Window time.Duration
+	// Authorize, if set, is called before sponsoring a transaction of sender, e.g. to
+	// check that the request comes from a session of that user.
+	Authorize func(r *http.Request, sender string) error
+	// ErrorLog, if set, logs the errors answered with a generic 502, whose details are
+	// not returned to the client.
+	ErrorLog *log.Logger
+}
+
+// NewHandler returns the handler of a gas station sponsoring transactions with
+// cfg.Sponsor. Spending is tracked in memory by gas budget, the most a sponsored
+// transaction can cost.
+func NewHandler(cfg Config) http.Handler {
+	if cfg.Window <= 0 {
+		cfg.Window = defaultWindow
+	}
+	policies := []transaction.Policy{
+		transaction.AllowPackages(cfg.AllowedPackages...),
+		transaction.ForbidPublish(),
+	}
+	if cfg.MaxGasBudget > 0 {
+		policies = append(policies, transaction.MaxGasBudget(cfg.MaxGasBudget))
+	}
+	s := &station{
+		cfg:    cfg,
+		policy: transaction.Policies(policies...),
+		spends: map[string][]spend{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(sponsorPath, s.sponsor)
+	return mux
+}
+
+type spend struct {
+	at     time.Time
+	amount uint64
+}
+
+type station struct {
+	cfg    Config
+	policy transaction.Policy
+
+	mu     sync.Mutex
+	spends map[string][]spend
+	total  []spend
+}
+
+func (s *station) sponsor(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req SponsorRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if !utils.IsValidMgoAddress(model.MgoAddress(req.Sender)) || req.TxKindBytes == "" {
+		http.Error(w, "sender and txKindBytes are required", http.StatusBadRequest)
+		return
+	}
+	sender := string(utils.NormalizeMgoAddress(req.Sender))
+	if s.cfg.Authorize != nil {
+		if err := s.cfg.Authorize(r, sender); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	var reserved *spend
+	policy := transaction.Policies(s.policy, transaction.PolicyFunc(func(preview *transaction.TransactionPreview) error {
+		var err error
+		reserved, err = s.reserve(sender, preview.GasBudget)
+		return err
+	}))
+	sponsored, err := s.cfg.Sponsor.SponsorWithPolicy(r.Context(), model.MgoAddress(sender), req.TxKindBytes, policy)
+	if err != nil {
+		if reserved != nil {
+			s.release(sender, reserved)
+		}
+		var dryRunErr *transaction.DryRunError
+		switch {
+		case errors.Is(err, ErrSpendLimit):
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+		case errors.Is(err, transaction.ErrPolicyViolation):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, transaction.ErrInvalidTransactionKind):
+			http.Error(w, "invalid txKindBytes", http.StatusBadRequest)
+		case errors.Is(err, transaction.ErrInvalidMgoAddress):
+			http.Error(w, "invalid sender", http.StatusBadRequest)
+		case errors.As(err, &dryRunErr):
+			http.Error(w, dryRunErr.Error(), http.StatusBadRequest)
+		default:
+			if s.cfg.ErrorLog != nil {
+				s.cfg.ErrorLog.Printf("gasstation: sponsor transaction of %s: %v", sender, err)
+			}
+			http.Error(w, "failed to sponsor transaction", http.StatusBadGateway)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sponsored)
+}
+
+// reserve records a spend of amount for sender unless it exceeds a spend limit.
+func (s *station) reserve(sender string, amount uint64) (*spend, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	since := time.Now().Add(-s.cfg.Window)
+	s.total = expire(s.total, since)
+	s.spends[sender] = expire(s.spends[sender], since)
+	if len(s.spends[sender]) == 0 {
+		delete(s.spends, sender)
+	}
+
+	if limit := s.cfg.MaxSpendPerSender; limit > 0 && sum(s.spends[sender])+amount > limit {
+		return nil, fmt.Errorf("%w: sender %s", ErrSpendLimit, sender)
+	}
+	if limit := s.cfg.MaxSpend; limit > 0 && sum(s.total)+amount > limit {
+		return nil, ErrSpendLimit
+	}
+
+	reserved := &spend{at: time.Now(), amount: amount}
+	s.spends[sender] = append(s.spends[sender], *reserved)
+	s.total = append(s.total, *reserved)
+	return reserved, nil
+}
+
+// release removes a reserved spend of a transaction that was not sponsored.
+func (s *station) release(sender string, reserved *spend) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spends[sender] = remove(s.spends[sender], *reserved)
+	s.total = remove(s.total, *reserved)
+}
+
+func expire(spends []spend, since time.Time) []spend {
+	i := 0
+	for i < len(spends) && spends[i].at.Before(since) {
+		i++
+	}
+	return spends[i:]
+}
+
+func remove(spends []spend, reserved spend) []spend {
+	for i := range spends {
+		if spends[i] == reserved {
+			return append(spends[:i:i], spends[i+1:]...)
+		}
+	}
+	return spends
+}
+
+func sum(spends []spend) uint64 {
+	var total uint64
+	for _, spend := range spends {
+		total += spend.amount
+	}
+	return total
+}
@@ -0,0 +1,144 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/utils"
+)
+
+// normalizedType is a Move type as reported by `mgo_getNormalizedMoveFunction`, e.g.
+// "U64", {"Vector": "U8"} or {"MutableReference": {"Struct": {...}}}.
+type normalizedType struct {
+	Primitive     string
+	Vector        *normalizedType
+	Struct        *normalizedStruct
+	TypeParameter *uint64
+	Reference     bool
+	Mutable       bool
+}
+
+type normalizedStruct struct {
+	Address       string
+	Module        string
+	Name          string
+	TypeArguments []*normalizedType
+}
+
+func parseNormalizedType(v any) (*normalizedType, error) {
+	switch value := v.(type) {
+	case string:
+		return &normalizedType{Primitive: value}, nil
+	case map[string]any:
+		if inner, ok := value["Reference"]; ok {
+			t, err := parseNormalizedType(inner)
+			if err != nil {
+				return nil, err
+			}
+			t.Reference = true
+			return t, nil
+		}
+		if inner, ok := value["MutableReference"]; ok {
+			t, err := parseNormalizedType(inner)
+			if err != nil {
+				return nil, err
+			}
+			t.Reference = true
+			t.Mutable = true
+			return t, nil
+		}
+		if inner, ok := value["Vector"]; ok {
+			t, err := parseNormalizedType(inner)
+			if err != nil {
+				return nil, err
+			}
+			return &normalizedType{Vector: t}, nil
+		}
+		if inner, ok := value["TypeParameter"]; ok {
+			index, ok := inner.(float64)
+			if !ok {
+				return nil, fmt.Errorf("invalid type parameter index %v", inner)
+			}
+			i := uint64(index)
+			return &normalizedType{TypeParameter: &i}, nil
+		}
+		if inner, ok := value["Struct"].(map[string]any); ok {
+			s := &normalizedStruct{}
+			s.Address, _ = inner["address"].(string)
+			s.Module, _ = inner["module"].(string)
+			s.Name, _ = inner["name"].(string)
+			typeArguments, _ := inner["typeArguments"].([]any)
+			for _, typeArgument := range typeArguments {
+				t, err := parseNormalizedType(typeArgument)
+				if err != nil {
+					return nil, err
+				}
+				s.TypeArguments = append(s.TypeArguments, t)
+			}
+			return &normalizedType{Struct: s}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported normalized move type %v", v)
+}
+
+// isStruct reports whether t is the struct address::module::name, regardless of its type arguments.
+func (t *normalizedType) isStruct(address, module, name string) bool {
+	if t.Struct == nil {
+		return false
+	}
+
+	return utils.NormalizeMgoAddress(t.Struct.Address) == utils.NormalizeMgoAddress(address) &&
+		t.Struct.Module == module &&
+		t.Struct.Name == name
+}
+
+func (t *normalizedType) isTxContext() bool {
+	return t.isStruct("0x2", "tx_context", "TxContext")
+}
+
+func (t *normalizedType) isReceiving() bool {
+	return t.isStruct("0x2", "transfer", "Receiving")
+}
+
+// moveFunctionResolver fetches and caches the parameter types of the Move functions
+// called by a transaction.
+type moveFunctionResolver struct {
+	client    *client.Client
+	functions map[string][]*normalizedType
+}
+
+// parameters returns the parameter types of the function called by moveCall.
+func (r *moveFunctionResolver) parameters(ctx context.Context, moveCall *ProgrammableMoveCall) ([]*normalizedType, error) {
+	packageId := ConvertMgoAddressBytesToString(moveCall.Package)
+	key := fmt.Sprintf("%s::%s::%s", packageId, moveCall.Module, moveCall.Function)
+	if parameters, ok := r.functions[key]; ok {
+		return parameters, nil
+	}
+
+	rsp, err := r.client.MgoGetNormalizedMoveFunction(ctx, request.GetNormalizedMoveFunctionRequest{
+		Package:      string(packageId),
+		ModuleName:   moveCall.Module,
+		FunctionName: moveCall.Function,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := make([]*normalizedType, 0, len(rsp.Parameters))
+	for _, parameter := range rsp.Parameters {
+		t, err := parseNormalizedType(parameter)
+		if err != nil {
+			return nil, fmt.Errorf("function %s: %w", key, err)
+		}
+		parameters = append(parameters, t)
+	}
+	if r.functions == nil {
+		r.functions = map[string][]*normalizedType{}
+	}
+	r.functions[key] = parameters
+
+	return parameters, nil
+}
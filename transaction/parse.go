@@ -0,0 +1,70 @@
+package transaction
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+)
+
+// ParseTransactionData decodes base64 encoded BCS transaction data, such as the
+// `TxBytes` of a `TxnMetaData` or the bytes produced by `Transaction.build`.
+func ParseTransactionData(txBytes string) (*TransactionData, error) {
+	b, err := bcs.FromBase64(txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return UnmarshalTransactionData(b)
+}
+
+// UnmarshalTransactionData decodes BCS transaction data. Only programmable transactions are supported.
+func UnmarshalTransactionData(b []byte) (*TransactionData, error) {
+	var data TransactionData
+	n, err := bcs.Unmarshal(b, &data)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(b) {
+		return nil, fmt.Errorf("%d trailing bytes after transaction data", len(b)-n)
+	}
+
+	return &data, nil
+}
+
+// ParseTransactionKind decodes a base64 encoded BCS transaction kind, as produced by
+// building a transaction with only its kind.
+func ParseTransactionKind(txKindBytes string) (*TransactionKind, error) {
+	b, err := bcs.FromBase64(txKindBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var kind TransactionKind
+	n, err := bcs.Unmarshal(b, &kind)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(b) {
+		return nil, fmt.Errorf("%d trailing bytes after transaction kind", len(b)-n)
+	}
+
+	return &kind, nil
+}
+
+// UnmarshalBCS decodes a transaction kind. System transaction kinds are rejected with
+// ErrUnsupportedTransactionKind since their contents are not modelled.
+func (tk *TransactionKind) UnmarshalBCS(r io.Reader) (int, error) {
+	variant, n, err := bcs.ULEB128Decode[int](r)
+	if err != nil {
+		return n, err
+	}
+	if variant != 0 {
+		return n, fmt.Errorf("%w: variant %d", ErrUnsupportedTransactionKind, variant)
+	}
+
+	*tk = TransactionKind{ProgrammableTransaction: &ProgrammableTransaction{}}
+	k, err := bcs.NewDecoder(r).Decode(tk.ProgrammableTransaction)
+
+	return n + k, err
+}
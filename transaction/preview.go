@@ -0,0 +1,305 @@
+package transaction
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/utils"
+)
+
+var ErrPolicyViolation = errors.New("transaction violates signing policy")
+
+// TransactionPreview summarizes what a transaction will do once executed.
+type TransactionPreview struct {
+	Sender          string
+	GasOwner        string
+	GasBudget       uint64
+	GasPrice        uint64
+	GasPayment      []string
+	ExpirationEpoch *uint64
+	Commands        []string
+	MoveCalls       []MoveCallPreview
+	Transfers       []TransferPreview
+	SplitCoins      []SplitCoinsPreview
+	Publishes       int
+	Upgrades        []string
+
+	Data *TransactionData
+}
+
+type MoveCallPreview struct {
+	Package       string
+	Module        string
+	Function      string
+	TypeArguments []string
+}
+
+func (m MoveCallPreview) String() string {
+	s := fmt.Sprintf("%s::%s::%s", m.Package, m.Module, m.Function)
+	if len(m.TypeArguments) > 0 {
+		s += "<" + strings.Join(m.TypeArguments, ", ") + ">"
+	}
+
+	return s
+}
+
+// TransferPreview describes a TransferObjects command. Recipient is empty when the
+// recipient is not a pure input, e.g. the result of a Move call.
+type TransferPreview struct {
+	Recipient string
+	Objects   []string
+}
+
+// SplitCoinsPreview describes a SplitCoins command. Amounts that are not pure u64
+// inputs are reported as nil.
+type SplitCoinsPreview struct {
+	Coin    string
+	Amounts []*uint64
+}
+
+// PreviewTransaction decodes base64 encoded transaction data, such as the `TxBytes`
+// handed to `Keypair.SignTransactionBlock`, and summarizes it.
+func PreviewTransaction(txBytes string) (*TransactionPreview, error) {
+	data, err := ParseTransactionData(txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTransactionPreview(data)
+}
+
+// NewTransactionPreview summarizes decoded transaction data.
+func NewTransactionPreview(data *TransactionData) (*TransactionPreview, error) {
+	v1 := data.V1
+	if v1 == nil || v1.Kind == nil || v1.Kind.ProgrammableTransaction == nil || v1.GasData == nil {
+		return nil, ErrUnsupportedTransactionKind
+	}
+
+	preview := &TransactionPreview{Data: data}
+	if v1.Sender != nil {
+		preview.Sender = string(ConvertMgoAddressBytesToString(*v1.Sender))
+	}
+	if v1.GasData.Owner != nil {
+		preview.GasOwner = string(ConvertMgoAddressBytesToString(*v1.GasData.Owner))
+	}
+	if v1.GasData.Budget != nil {
+		preview.GasBudget = *v1.GasData.Budget
+	}
+	if v1.GasData.Price != nil {
+		preview.GasPrice = *v1.GasData.Price
+	}
+	if v1.GasData.Payment != nil {
+		for _, ref := range *v1.GasData.Payment {
+			preview.GasPayment = append(preview.GasPayment, string(ConvertMgoAddressBytesToString(ref.ObjectId)))
+		}
+	}
+	if v1.Expiration != nil && v1.Expiration.Epoch != nil {
+		epoch := *v1.Expiration.Epoch
+		preview.ExpirationEpoch = &epoch
+	}
+
+	inputs := v1.Kind.ProgrammableTransaction.Inputs
+	for _, command := range v1.Kind.ProgrammableTransaction.Commands {
+		switch {
+		case command.MoveCall != nil:
+			moveCall := MoveCallPreview{
+				Package:  string(ConvertMgoAddressBytesToString(command.MoveCall.Package)),
+				Module:   command.MoveCall.Module,
+				Function: command.MoveCall.Function,
+			}
+			for _, typeArgument := range command.MoveCall.TypeArguments {
+				moveCall.TypeArguments = append(moveCall.TypeArguments, typeArgument.String())
+			}
+			preview.MoveCalls = append(preview.MoveCalls, moveCall)
+			preview.Commands = append(preview.Commands, "MoveCall "+moveCall.String())
+		case command.TransferObjects != nil:
+			transfer := TransferPreview{}
+			if address := pureInput(inputs, command.TransferObjects.Address); len(address) == 32 {
+				transfer.Recipient = fmt.Sprintf("0x%x", address)
+			}
+			for _, object := range command.TransferObjects.Objects {
+				transfer.Objects = append(transfer.Objects, describeArgument(inputs, object))
+			}
+			preview.Transfers = append(preview.Transfers, transfer)
+			recipient := transfer.Recipient
+			if recipient == "" {
+				recipient = describeArgument(inputs, command.TransferObjects.Address)
+			}
+			preview.Commands = append(preview.Commands, fmt.Sprintf("TransferObjects [%s] to %s", strings.Join(transfer.Objects, ", "), recipient))
+		case command.SplitCoins != nil:
+			split := SplitCoinsPreview{Coin: describeArgument(inputs, command.SplitCoins.Coin)}
+			amounts := make([]string, len(command.SplitCoins.Amount))
+			for i, amount := range command.SplitCoins.Amount {
+				var value *uint64
+				if b := pureInput(inputs, amount); len(b) == 8 {
+					v := binary.LittleEndian.Uint64(b)
+					value = &v
+					amounts[i] = fmt.Sprintf("%d", v)
+				} else {
+					amounts[i] = describeArgument(inputs, amount)
+				}
+				split.Amounts = append(split.Amounts, value)
+			}
+			preview.SplitCoins = append(preview.SplitCoins, split)
+			preview.Commands = append(preview.Commands, fmt.Sprintf("SplitCoins %s into [%s]", split.Coin, strings.Join(amounts, ", ")))
+		case command.MergeCoins != nil:
+			sources := make([]string, len(command.MergeCoins.Sources))
+			for i, source := range command.MergeCoins.Sources {
+				sources[i] = describeArgument(inputs, source)
+			}
+			preview.Commands = append(preview.Commands, fmt.Sprintf("MergeCoins [%s] into %s", strings.Join(sources, ", "), describeArgument(inputs, command.MergeCoins.Destination)))
+		case command.MakeMoveVec != nil:
+			preview.Commands = append(preview.Commands, fmt.Sprintf("MakeMoveVec of %d elements", len(command.MakeMoveVec.Elements)))
+		case command.Publish != nil:
+			preview.Publishes++
+			preview.Commands = append(preview.Commands, fmt.Sprintf("Publish %d modules", len(command.Publish.Modules)))
+		case command.Upgrade != nil:
+			packageId := string(ConvertMgoAddressBytesToString(command.Upgrade.Package))
+			preview.Upgrades = append(preview.Upgrades, packageId)
+			preview.Commands = append(preview.Commands, "Upgrade "+packageId)
+		}
+	}
+
+	return preview, nil
+}
+
+// String renders the preview as text suitable for showing to a person approving the transaction.
+func (p *TransactionPreview) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sender: %s\n", p.Sender)
+	fmt.Fprintf(&b, "Gas: owner %s, budget %d, price %d, payment [%s]\n", p.GasOwner, p.GasBudget, p.GasPrice, strings.Join(p.GasPayment, ", "))
+	if p.ExpirationEpoch != nil {
+		fmt.Fprintf(&b, "Expires after epoch %d\n", *p.ExpirationEpoch)
+	} else {
+		b.WriteString("Expiration: none\n")
+	}
+	b.WriteString("Commands:\n")
+	for i, command := range p.Commands {
+		fmt.Fprintf(&b, "  %d. %s\n", i, command)
+	}
+
+	return b.String()
+}
+
+func pureInput(inputs []*CallArg, arg *Argument) []byte {
+	if arg == nil || arg.Input == nil || int(*arg.Input) >= len(inputs) {
+		return nil
+	}
+	if pure := inputs[*arg.Input].Pure; pure != nil {
+		return pure.Bytes
+	}
+
+	return nil
+}
+
+func describeArgument(inputs []*CallArg, arg *Argument) string {
+	switch {
+	case arg == nil:
+		return "<none>"
+	case arg.GasCoin != nil:
+		return "GasCoin"
+	case arg.Input != nil:
+		if int(*arg.Input) < len(inputs) && inputs[*arg.Input].Object != nil {
+			object := inputs[*arg.Input].Object
+			switch {
+			case object.ImmOrOwnedObject != nil:
+				return string(ConvertMgoAddressBytesToString(object.ImmOrOwnedObject.ObjectId))
+			case object.SharedObject != nil:
+				return string(ConvertMgoAddressBytesToString(object.SharedObject.ObjectId))
+			case object.Receiving != nil:
+				return string(ConvertMgoAddressBytesToString(object.Receiving.ObjectId))
+			}
+		}
+		return fmt.Sprintf("Input(%d)", *arg.Input)
+	case arg.Result != nil:
+		return fmt.Sprintf("Result(%d)", *arg.Result)
+	case arg.NestedResult != nil:
+		return fmt.Sprintf("NestedResult(%d, %d)", arg.NestedResult.Index, arg.NestedResult.ResultIndex)
+	default:
+		return "<unknown>"
+	}
+}
+
+// Policy decides whether a transaction may be signed.
+type Policy interface {
+	Check(preview *TransactionPreview) error
+}
+
+// PolicyFunc adapts a function to a Policy.
+type PolicyFunc func(preview *TransactionPreview) error
+
+func (f PolicyFunc) Check(preview *TransactionPreview) error {
+	return f(preview)
+}
+
+// Policies combines policies; a transaction must satisfy all of them.
+func Policies(policies ...Policy) Policy {
+	return PolicyFunc(func(preview *TransactionPreview) error {
+		for _, policy := range policies {
+			if err := policy.Check(preview); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AllowPackages only allows Move calls into the given packages.
+func AllowPackages(packages ...string) Policy {
+	allowed := map[string]bool{}
+	for _, p := range packages {
+		allowed[string(utils.NormalizeMgoAddress(p))] = true
+	}
+
+	return PolicyFunc(func(preview *TransactionPreview) error {
+		for _, moveCall := range preview.MoveCalls {
+			if !allowed[moveCall.Package] {
+				return fmt.Errorf("%w: package %s is not allowed", ErrPolicyViolation, moveCall.Package)
+			}
+		}
+		return nil
+	})
+}
+
+// MaxGasBudget rejects transactions whose gas budget exceeds maxBudget.
+func MaxGasBudget(maxBudget uint64) Policy {
+	return PolicyFunc(func(preview *TransactionPreview) error {
+		if preview.GasBudget > maxBudget {
+			return fmt.Errorf("%w: gas budget %d exceeds %d", ErrPolicyViolation, preview.GasBudget, maxBudget)
+		}
+		return nil
+	})
+}
+
+// ForbidPublish rejects transactions that publish or upgrade packages.
+func ForbidPublish() Policy {
+	return PolicyFunc(func(preview *TransactionPreview) error {
+		if preview.Publishes > 0 {
+			return fmt.Errorf("%w: publishing packages is not allowed", ErrPolicyViolation)
+		}
+		if len(preview.Upgrades) > 0 {
+			return fmt.Errorf("%w: upgrading packages is not allowed", ErrPolicyViolation)
+		}
+		return nil
+	})
+}
+
+// SignTransactionBlockWithPolicy previews txn and signs it only if it satisfies policy.
+func SignTransactionBlockWithPolicy(ctx context.Context, signer keypair.KeySigner, txn *model.TxnMetaData, policy Policy) (*keypair.SignedTransactionSerializedSig, error) {
+	preview, err := PreviewTransaction(txn.TxBytes)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		if err := policy.Check(preview); err != nil {
+			return nil, err
+		}
+	}
+
+	return keypair.SignTransactionBlock(ctx, signer, txn)
+}
@@ -0,0 +1,371 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/utils"
+)
+
+var (
+	u64Type     = &normalizedType{Primitive: "U64"}
+	addressType = &normalizedType{Primitive: "Address"}
+)
+
+// resolvePureValues encodes every UnresolvedPure input according to the type it is used as:
+// the parameter type of the Move function it is passed to, u64 for SplitCoins amounts and
+// address for TransferObjects recipients.
+func (tx *Transaction) resolvePureValues(ctx context.Context, resolver *moveFunctionResolver) error {
+	inputs := tx.Data.V1.Kind.ProgrammableTransaction.Inputs
+
+	hasUnresolved := false
+	for _, input := range inputs {
+		if input.UnresolvedPure != nil {
+			hasUnresolved = true
+			break
+		}
+	}
+	if !hasUnresolved {
+		return nil
+	}
+
+	encoded := map[uint16][]byte{}
+	encode := func(arg *Argument, t *normalizedType, describe func() string) error {
+		if arg == nil || arg.Input == nil || int(*arg.Input) >= len(inputs) {
+			return nil
+		}
+		input := inputs[*arg.Input]
+		if input.UnresolvedPure == nil {
+			return nil
+		}
+		value, err := encodePureValue(t, input.UnresolvedPure.Value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", describe(), err)
+		}
+		if previous, ok := encoded[*arg.Input]; ok && !bytes.Equal(previous, value) {
+			return fmt.Errorf("%s: input %d is used with conflicting types", describe(), *arg.Input)
+		}
+		encoded[*arg.Input] = value
+
+		return nil
+	}
+
+	for commandIndex, command := range tx.Data.V1.Kind.ProgrammableTransaction.Commands {
+		switch {
+		case command.MoveCall != nil:
+			moveCall := command.MoveCall
+			if !hasUnresolvedPureArgument(inputs, moveCall.Arguments) {
+				continue
+			}
+			if tx.MgoClient == nil {
+				return ErrMgoClientNotSet
+			}
+			parameters, err := resolver.parameters(ctx, moveCall)
+			if err != nil {
+				return err
+			}
+			for i, arg := range moveCall.Arguments {
+				if i >= len(parameters) {
+					return fmt.Errorf("too many arguments for %s::%s", moveCall.Module, moveCall.Function)
+				}
+				parameter, err := parameters[i].substitute(moveCall.TypeArguments)
+				if err != nil {
+					return fmt.Errorf("argument %d of %s::%s: %w", i, moveCall.Module, moveCall.Function, err)
+				}
+				err = encode(arg, parameter, func() string {
+					return fmt.Sprintf("argument %d of %s::%s", i, moveCall.Module, moveCall.Function)
+				})
+				if err != nil {
+					return err
+				}
+			}
+		case command.SplitCoins != nil:
+			for i, arg := range command.SplitCoins.Amount {
+				err := encode(arg, u64Type, func() string {
+					return fmt.Sprintf("amount %d of SplitCoins command %d", i, commandIndex)
+				})
+				if err != nil {
+					return err
+				}
+			}
+		case command.TransferObjects != nil:
+			err := encode(command.TransferObjects.Address, addressType, func() string {
+				return fmt.Sprintf("recipient of TransferObjects command %d", commandIndex)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, input := range inputs {
+		if input.UnresolvedPure == nil {
+			continue
+		}
+		value, ok := encoded[uint16(i)]
+		if !ok {
+			return fmt.Errorf("input %d: %w", i, ErrUnresolvedPureType)
+		}
+		input.Pure = &Pure{Bytes: value}
+		input.UnresolvedPure = nil
+	}
+
+	return nil
+}
+
+func hasUnresolvedPureArgument(inputs []*CallArg, args []*Argument) bool {
+	for _, arg := range args {
+		if arg != nil && arg.Input != nil && int(*arg.Input) < len(inputs) && inputs[*arg.Input].UnresolvedPure != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// substitute replaces the type parameters of t with the type arguments of the Move call.
+func (t *normalizedType) substitute(typeArguments []*TypeTag) (*normalizedType, error) {
+	switch {
+	case t.TypeParameter != nil:
+		if *t.TypeParameter >= uint64(len(typeArguments)) || typeArguments[*t.TypeParameter] == nil {
+			return nil, fmt.Errorf("missing type argument %d", *t.TypeParameter)
+		}
+		substituted, err := normalizedTypeFromTypeTag(typeArguments[*t.TypeParameter])
+		if err != nil {
+			return nil, err
+		}
+		substituted.Reference = t.Reference
+		substituted.Mutable = t.Mutable
+		return substituted, nil
+	case t.Vector != nil:
+		element, err := t.Vector.substitute(typeArguments)
+		if err != nil {
+			return nil, err
+		}
+		return &normalizedType{Vector: element, Reference: t.Reference, Mutable: t.Mutable}, nil
+	case t.Struct != nil && len(t.Struct.TypeArguments) > 0:
+		s := *t.Struct
+		s.TypeArguments = make([]*normalizedType, len(t.Struct.TypeArguments))
+		for i, typeArgument := range t.Struct.TypeArguments {
+			substituted, err := typeArgument.substitute(typeArguments)
+			if err != nil {
+				return nil, err
+			}
+			s.TypeArguments[i] = substituted
+		}
+		return &normalizedType{Struct: &s, Reference: t.Reference, Mutable: t.Mutable}, nil
+	default:
+		return t, nil
+	}
+}
+
+func normalizedTypeFromTypeTag(tag *TypeTag) (*normalizedType, error) {
+	switch {
+	case tag.Bool != nil:
+		return &normalizedType{Primitive: "Bool"}, nil
+	case tag.U8 != nil:
+		return &normalizedType{Primitive: "U8"}, nil
+	case tag.U16 != nil:
+		return &normalizedType{Primitive: "U16"}, nil
+	case tag.U32 != nil:
+		return &normalizedType{Primitive: "U32"}, nil
+	case tag.U64 != nil:
+		return &normalizedType{Primitive: "U64"}, nil
+	case tag.U128 != nil:
+		return &normalizedType{Primitive: "U128"}, nil
+	case tag.U256 != nil:
+		return &normalizedType{Primitive: "U256"}, nil
+	case tag.Address != nil:
+		return &normalizedType{Primitive: "Address"}, nil
+	case tag.Signer != nil:
+		return &normalizedType{Primitive: "Signer"}, nil
+	case tag.Vector != nil:
+		element, err := normalizedTypeFromTypeTag(tag.Vector)
+		if err != nil {
+			return nil, err
+		}
+		return &normalizedType{Vector: element}, nil
+	case tag.Struct != nil:
+		s := &normalizedStruct{
+			Address: string(ConvertMgoAddressBytesToString(tag.Struct.Address)),
+			Module:  tag.Struct.Module,
+			Name:    tag.Struct.Name,
+		}
+		for _, typeParam := range tag.Struct.TypeParams {
+			t, err := normalizedTypeFromTypeTag(typeParam)
+			if err != nil {
+				return nil, err
+			}
+			s.TypeArguments = append(s.TypeArguments, t)
+		}
+		return &normalizedType{Struct: s}, nil
+	default:
+		return nil, errors.New("empty type tag")
+	}
+}
+
+// encodePureValue BCS encodes value as the Move type t. Supported types are bool,
+// u8 to u256, address, vector<T>, 0x1::string::String, 0x1::ascii::String,
+// 0x1::option::Option<T> and 0x2::object::ID.
+func encodePureValue(t *normalizedType, value any) ([]byte, error) {
+	if t.Mutable {
+		return nil, errors.New("mutable references cannot be passed as pure values")
+	}
+
+	switch {
+	case t.Primitive != "":
+		return encodePurePrimitive(t.Primitive, value)
+	case t.Vector != nil:
+		return encodePureVector(t.Vector, value)
+	case t.isStruct("0x1", "string", "String"), t.isStruct("0x1", "ascii", "String"):
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+		if t.Struct.Module == "ascii" {
+			for _, c := range s {
+				if c > 0x7f {
+					return nil, fmt.Errorf("%q is not an ascii string", s)
+				}
+			}
+		}
+		return bcs.Marshal(s)
+	case t.isStruct("0x2", "object", "ID"):
+		return encodePurePrimitive("Address", value)
+	case t.isStruct("0x1", "option", "Option"):
+		if len(t.Struct.TypeArguments) != 1 {
+			return nil, errors.New("option must have exactly one type argument")
+		}
+		v := reflect.ValueOf(value)
+		if value == nil || (v.Kind() == reflect.Pointer && v.IsNil()) {
+			return []byte{0}, nil
+		}
+		if v.Kind() == reflect.Pointer {
+			value = v.Elem().Interface()
+		}
+		inner, err := encodePureValue(t.Struct.TypeArguments[0], value)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{1}, inner...), nil
+	case t.Struct != nil:
+		return nil, fmt.Errorf("%s::%s::%s cannot be passed as a pure value", t.Struct.Address, t.Struct.Module, t.Struct.Name)
+	default:
+		return nil, errors.New("generic type parameters cannot be passed as pure values")
+	}
+}
+
+func encodePurePrimitive(primitive string, value any) ([]byte, error) {
+	switch primitive {
+	case "Bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool, got %T", value)
+		}
+		return bcs.Marshal(b)
+	case "U8", "U16", "U32", "U64", "U128", "U256":
+		size := map[string]int{"U8": 1, "U16": 2, "U32": 4, "U64": 8, "U128": 16, "U256": 32}[primitive]
+		n, err := pureInteger(value)
+		if err != nil {
+			return nil, err
+		}
+		if n.Sign() < 0 || n.BitLen() > size*8 {
+			return nil, fmt.Errorf("%s out of range for %s", n.String(), primitive)
+		}
+		return littleEndian(n, size), nil
+	case "Address":
+		switch v := value.(type) {
+		case model.MgoAddressBytes:
+			return v[:], nil
+		case *model.MgoAddressBytes:
+			return v[:], nil
+		case string, model.MgoAddress:
+			address := model.MgoAddress(reflect.ValueOf(v).String())
+			if !utils.IsValidMgoAddress(address) {
+				return nil, fmt.Errorf("%q is not a valid address", address)
+			}
+			addressBytes, err := ConvertMgoAddressStringToBytes(address)
+			if err != nil {
+				return nil, err
+			}
+			return addressBytes[:], nil
+		default:
+			return nil, fmt.Errorf("expected address, got %T", value)
+		}
+	default:
+		return nil, fmt.Errorf("%s cannot be passed as a pure value", primitive)
+	}
+}
+
+func encodePureVector(element *normalizedType, value any) ([]byte, error) {
+	if element.Primitive == "U8" {
+		switch v := value.(type) {
+		case []byte:
+			return bcs.Marshal(v)
+		case string:
+			return bcs.Marshal([]byte(v))
+		}
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected slice for vector, got %T", value)
+	}
+	encoded := bcs.ULEB128Encode(v.Len())
+	for i := 0; i < v.Len(); i++ {
+		item, err := encodePureValue(element, v.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		encoded = append(encoded, item...)
+	}
+
+	return encoded, nil
+}
+
+func pureInteger(value any) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, errors.New("expected integer, got nil")
+		}
+		return v, nil
+	case big.Int:
+		return &v, nil
+	case string:
+		n, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a decimal integer", v)
+		}
+		return n, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	default:
+		return nil, fmt.Errorf("expected integer, got %T", value)
+	}
+}
+
+func littleEndian(n *big.Int, size int) []byte {
+	if size == 8 {
+		return binary.LittleEndian.AppendUint64(nil, n.Uint64())
+	}
+	bigEndian := n.FillBytes(make([]byte, size))
+	for i, j := 0, len(bigEndian)-1; i < j; i, j = i+1, j-1 {
+		bigEndian[i], bigEndian[j] = bigEndian[j], bigEndian[i]
+	}
+
+	return bigEndian
+}
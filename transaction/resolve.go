@@ -0,0 +1,217 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/model/response"
+)
+
+// maxMultiGetObjects is the number of objects requested per `mgo_multiGetObjects` call.
+const maxMultiGetObjects = 50
+
+// inputUsage records how the commands of a transaction use one of its inputs.
+type inputUsage struct {
+	mutable   bool
+	receiving bool
+}
+
+// resolveObjects replaces every UnresolvedObject input with a concrete ObjectArg.
+// Owned and immutable objects become ImmOrOwnedObject, shared objects become SharedObject
+// with their initial shared version, and objects passed as `0x2::transfer::Receiving`
+// become Receiving. A shared object is mutable unless every Move call only takes it
+// by immutable reference.
+func (tx *Transaction) resolveObjects(ctx context.Context, resolver *moveFunctionResolver) error {
+	inputs := tx.Data.V1.Kind.ProgrammableTransaction.Inputs
+
+	var unresolved []int
+	for i, input := range inputs {
+		if input.UnresolvedObject != nil {
+			unresolved = append(unresolved, i)
+		}
+	}
+	if len(unresolved) == 0 {
+		return nil
+	}
+	if tx.MgoClient == nil {
+		return ErrMgoClientNotSet
+	}
+
+	usages, err := tx.inputUsages(ctx, resolver)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(unresolved); start += maxMultiGetObjects {
+		end := min(start+maxMultiGetObjects, len(unresolved))
+		batch := unresolved[start:end]
+
+		objectIds := make([]string, len(batch))
+		for i, index := range batch {
+			objectIds[i] = string(ConvertMgoAddressBytesToString(inputs[index].UnresolvedObject.ObjectId))
+		}
+		objects, err := tx.MgoClient.MgoMultiGetObjects(ctx, request.MgoMultiGetObjectsRequest{
+			ObjectIds: objectIds,
+			Options: request.MgoObjectDataOptions{
+				ShowOwner: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if len(objects) != len(batch) {
+			return fmt.Errorf("expected %d objects, got %d", len(batch), len(objects))
+		}
+
+		for i, index := range batch {
+			objectArg, err := newObjectArg(objectIds[i], objects[i], usages[uint16(index)])
+			if err != nil {
+				return err
+			}
+			inputs[index].Object = objectArg
+			inputs[index].UnresolvedObject = nil
+		}
+	}
+
+	return nil
+}
+
+// inputUsages walks the commands of the transaction and records how each input is used.
+// Arguments of Move calls are matched against the parameter types of the called function.
+func (tx *Transaction) inputUsages(ctx context.Context, resolver *moveFunctionResolver) (map[uint16]*inputUsage, error) {
+	usages := map[uint16]*inputUsage{}
+	use := func(arg *Argument, parameter *normalizedType) {
+		if arg == nil || arg.Input == nil {
+			return
+		}
+		usage, ok := usages[*arg.Input]
+		if !ok {
+			usage = &inputUsage{}
+			usages[*arg.Input] = usage
+		}
+		if parameter == nil || !parameter.Reference || parameter.Mutable {
+			usage.mutable = true
+		}
+		if parameter != nil && parameter.isReceiving() {
+			usage.receiving = true
+		}
+	}
+
+	for _, command := range tx.Data.V1.Kind.ProgrammableTransaction.Commands {
+		switch {
+		case command.MoveCall != nil:
+			if !hasUnresolvedObjectArgument(tx.Data.V1.Kind.ProgrammableTransaction.Inputs, command.MoveCall.Arguments) {
+				continue
+			}
+			parameters, err := resolver.parameters(ctx, command.MoveCall)
+			if err != nil {
+				return nil, err
+			}
+			for i, arg := range command.MoveCall.Arguments {
+				if i >= len(parameters) {
+					return nil, fmt.Errorf("too many arguments for %s::%s", command.MoveCall.Module, command.MoveCall.Function)
+				}
+				use(arg, parameters[i])
+			}
+		case command.TransferObjects != nil:
+			for _, arg := range command.TransferObjects.Objects {
+				use(arg, nil)
+			}
+			use(command.TransferObjects.Address, nil)
+		case command.SplitCoins != nil:
+			use(command.SplitCoins.Coin, nil)
+			for _, arg := range command.SplitCoins.Amount {
+				use(arg, nil)
+			}
+		case command.MergeCoins != nil:
+			use(command.MergeCoins.Destination, nil)
+			for _, arg := range command.MergeCoins.Sources {
+				use(arg, nil)
+			}
+		case command.MakeMoveVec != nil:
+			for _, arg := range command.MakeMoveVec.Elements {
+				use(arg, nil)
+			}
+		case command.Upgrade != nil:
+			use(command.Upgrade.Ticket, nil)
+		}
+	}
+
+	return usages, nil
+}
+
+func hasUnresolvedObjectArgument(inputs []*CallArg, args []*Argument) bool {
+	for _, arg := range args {
+		if arg != nil && arg.Input != nil && int(*arg.Input) < len(inputs) && inputs[*arg.Input].UnresolvedObject != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newObjectArg(objectId string, object *response.MgoObjectResponse, usage *inputUsage) (*ObjectArg, error) {
+	if object == nil || object.Data == nil {
+		if object != nil && object.Error != nil {
+			return nil, fmt.Errorf("object %s: %s", objectId, object.Error.Code)
+		}
+		return nil, fmt.Errorf("object %s: not found", objectId)
+	}
+
+	if initialSharedVersion, ok := sharedObjectInitialVersion(object.Data.Owner); ok {
+		objectIdBytes, err := ConvertMgoAddressStringToBytes(model.MgoAddress(object.Data.ObjectId))
+		if err != nil {
+			return nil, err
+		}
+		return &ObjectArg{
+			SharedObject: &SharedObjectRef{
+				ObjectId:             *objectIdBytes,
+				InitialSharedVersion: initialSharedVersion,
+				Mutable:              usage != nil && usage.mutable,
+			},
+		}, nil
+	}
+
+	ref, err := NewMgoObjectRef(
+		model.MgoAddress(object.Data.ObjectId),
+		object.Data.Version,
+		model.ObjectDigest(object.Data.Digest),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if usage != nil && usage.receiving {
+		return &ObjectArg{Receiving: ref}, nil
+	}
+
+	return &ObjectArg{ImmOrOwnedObject: ref}, nil
+}
+
+// sharedObjectInitialVersion reads the initial shared version from an object owner
+// of the form {"Shared": {"initial_shared_version": 1}}.
+func sharedObjectInitialVersion(owner interface{}) (uint64, bool) {
+	ownerMap, ok := owner.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	shared, ok := ownerMap["Shared"].(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+
+	switch version := shared["initial_shared_version"].(type) {
+	case float64:
+		return uint64(version), true
+	case string:
+		v, err := strconv.ParseUint(version, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return v, true
+	default:
+		return 0, false
+	}
+}
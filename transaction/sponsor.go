@@ -0,0 +1,239 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/client"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/model/request"
+	"github.com/mangonet-labs/mgo-go-sdk/utils"
+)
+
+var (
+	ErrSponsorSignatureNotSet = errors.New("sponsor signature not set")
+	ErrSponsoredMismatch      = errors.New("sponsored transaction does not match the transaction kind")
+	ErrInvalidTransactionKind = errors.New("invalid transaction kind")
+)
+
+// BuildKind resolves the inputs of the transaction and returns its base64 encoded BCS
+// transaction kind, without sender or gas data, for a sponsor to pay its gas.
+func (tx *Transaction) BuildKind(ctx context.Context) (string, error) {
+	resolver := &moveFunctionResolver{client: tx.MgoClient}
+	if err := tx.resolveObjects(ctx, resolver); err != nil {
+		return "", err
+	}
+	if err := tx.resolvePureValues(ctx, resolver); err != nil {
+		return "", err
+	}
+
+	return tx.build(true)
+}
+
+// SponsoredTransaction is transaction data whose gas is paid by a sponsor, with the
+// signature of the sponsor. It needs the signature of the sender to be executed.
+type SponsoredTransaction struct {
+	TxBytes          string                  `json:"txBytes"`
+	Digest           model.TransactionDigest `json:"digest"`
+	SponsorSignature string                  `json:"sponsorSignature"`
+}
+
+// Sign checks that the sponsored transaction is txKindBytes, the transaction kind built
+// by the sender, sent by the signer, and returns the signature of the signer.
+func (s *SponsoredTransaction) Sign(ctx context.Context, signer keypair.KeySigner, txKindBytes string) (string, error) {
+	data, err := ParseTransactionData(s.TxBytes)
+	if err != nil {
+		return "", err
+	}
+	sender := model.MgoAddress(keypair.SignerMgoAddress(signer))
+	if data.V1.Sender == nil || ConvertMgoAddressBytesToString(*data.V1.Sender) != utils.NormalizeMgoAddress(string(sender)) {
+		return "", fmt.Errorf("%w: the sender is not %s", ErrSponsoredMismatch, sender)
+	}
+	kindBytes, err := data.V1.Kind.Marshal()
+	if err != nil {
+		return "", err
+	}
+	wantKindBytes, err := bcs.FromBase64(txKindBytes)
+	if err != nil {
+		return "", err
+	}
+	if !bytes.Equal(kindBytes, wantKindBytes) {
+		return "", fmt.Errorf("%w: the commands or inputs differ", ErrSponsoredMismatch)
+	}
+
+	signed, err := keypair.SignTransactionBlock(ctx, signer, &model.TxnMetaData{TxBytes: s.TxBytes})
+	if err != nil {
+		return "", err
+	}
+
+	return signed.Signature, nil
+}
+
+// ToMgoExecuteTransactionBlockRequest combines the signatures of the sponsor and the
+// sender into a request for MgoExecuteTransactionBlock.
+func (s *SponsoredTransaction) ToMgoExecuteTransactionBlockRequest(
+	senderSignature string,
+	options request.MgoTransactionBlockOptions,
+	requestType string,
+) (*request.MgoExecuteTransactionBlockRequest, error) {
+	if s.SponsorSignature == "" {
+		return nil, ErrSponsorSignatureNotSet
+	}
+
+	return &request.MgoExecuteTransactionBlockRequest{
+		TxBytes:     s.TxBytes,
+		Signature:   []string{s.SponsorSignature, senderSignature},
+		Options:     options,
+		RequestType: requestType,
+	}, nil
+}
+
+// Sponsor pays the gas of transactions built by others with the coins of its signer.
+type Sponsor struct {
+	client *client.Client
+	signer keypair.KeySigner
+	policy Policy
+
+	gasBudget             *uint64
+	gasBudgetSafetyMargin uint64
+}
+
+// NewSponsor returns a sponsor paying gas with the coins of signer. The gas budget is
+// estimated with a dry run plus a 20% safety margin unless SetGasBudget is called.
+func NewSponsor(cli *client.Client, signer keypair.KeySigner) *Sponsor {
+	return &Sponsor{
+		client:                cli,
+		signer:                signer,
+		gasBudgetSafetyMargin: 20,
+	}
+}
+
+// SetPolicy sets the policy sponsored transactions must satisfy.
+func (s *Sponsor) SetPolicy(policy Policy) *Sponsor {
+	s.policy = policy
+
+	return s
+}
+
+// SetGasBudget sets the gas budget of sponsored transactions.
+func (s *Sponsor) SetGasBudget(budget uint64) *Sponsor {
+	s.gasBudget = &budget
+
+	return s
+}
+
+// SetGasBudgetEstimation makes the sponsor estimate the gas budget with a dry run,
+// adding safetyMarginPercent on top of the estimated cost.
+func (s *Sponsor) SetGasBudgetEstimation(safetyMarginPercent uint64) *Sponsor {
+	s.gasBudget = nil
+	s.gasBudgetSafetyMargin = safetyMarginPercent
+
+	return s
+}
+
+// MgoAddress returns the address paying the gas.
+func (s *Sponsor) MgoAddress() model.MgoAddress {
+	return model.MgoAddress(keypair.SignerMgoAddress(s.signer))
+}
+
+// Sponsor sets the sponsor as gas owner of the transaction kind sent by sender, selects
+// its gas payment and signs the transaction if it satisfies the policy of the sponsor.
+// Transactions using the gas coin as an argument are rejected, since they could spend
+// the coins of the sponsor.
+func (s *Sponsor) Sponsor(ctx context.Context, sender model.MgoAddress, txKindBytes string) (*SponsoredTransaction, error) {
+	return s.SponsorWithPolicy(ctx, sender, txKindBytes, nil)
+}
+
+// SponsorWithPolicy sponsors like Sponsor, checking policy on top of the policy of the
+// sponsor.
+func (s *Sponsor) SponsorWithPolicy(ctx context.Context, sender model.MgoAddress, txKindBytes string, policy Policy) (*SponsoredTransaction, error) {
+	if s.client == nil {
+		return nil, ErrMgoClientNotSet
+	}
+	if !utils.IsValidMgoAddress(sender) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMgoAddress, sender)
+	}
+	kind, err := ParseTransactionKind(txKindBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTransactionKind, err)
+	}
+	if usesGasCoin(kind.ProgrammableTransaction) {
+		return nil, fmt.Errorf("%w: sponsored transactions cannot use the gas coin", ErrPolicyViolation)
+	}
+
+	tx := NewTransaction().SetMgoClient(s.client).SetSigner(s.signer)
+	tx.Data.V1.Kind = kind
+	tx.SetSender(sender)
+	tx.SetGasOwner(s.MgoAddress())
+	if s.gasBudget != nil {
+		tx.SetGasBudget(*s.gasBudget)
+	} else {
+		tx.SetGasBudgetEstimation(s.gasBudgetSafetyMargin)
+	}
+	txBytes, err := tx.buildTransaction(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := NewTransactionPreview(&tx.Data)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range []Policy{s.policy, policy} {
+		if p == nil {
+			continue
+		}
+		if err := p.Check(preview); err != nil {
+			return nil, err
+		}
+	}
+
+	digest, err := tx.Data.Digest()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := keypair.SignTransactionBlock(ctx, s.signer, &model.TxnMetaData{TxBytes: txBytes})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SponsoredTransaction{
+		TxBytes:          txBytes,
+		Digest:           digest,
+		SponsorSignature: signed.Signature,
+	}, nil
+}
+
+func usesGasCoin(pt *ProgrammableTransaction) bool {
+	if pt == nil {
+		return false
+	}
+	for _, command := range pt.Commands {
+		var args []*Argument
+		switch {
+		case command.MoveCall != nil:
+			args = command.MoveCall.Arguments
+		case command.TransferObjects != nil:
+			args = append([]*Argument{command.TransferObjects.Address}, command.TransferObjects.Objects...)
+		case command.SplitCoins != nil:
+			args = append([]*Argument{command.SplitCoins.Coin}, command.SplitCoins.Amount...)
+		case command.MergeCoins != nil:
+			args = append([]*Argument{command.MergeCoins.Destination}, command.MergeCoins.Sources...)
+		case command.MakeMoveVec != nil:
+			args = command.MakeMoveVec.Elements
+		case command.Upgrade != nil:
+			args = []*Argument{command.Upgrade.Ticket}
+		}
+		for _, arg := range args {
+			if arg != nil && arg.GasCoin != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"math"
 	"strconv"
+	"strings"
 
 	"github.com/jinzhu/copier"
 	"github.com/mangonet-labs/mgo-go-sdk/account/keypair"
@@ -19,9 +20,14 @@ import (
 
 type Transaction struct {
 	Data            TransactionData
-	Signer          *keypair.Keypair
-	SponsoredSigner *keypair.Keypair
+	Signer          keypair.KeySigner
+	SponsoredSigner keypair.KeySigner
 	MgoClient       *client.Client
+
+	gasBudgetSafetyMargin *uint64
+	// err is the first error of a command added to the transaction, returned when the
+	// transaction is built.
+	err error
 }
 
 func NewTransaction() *Transaction {
@@ -32,19 +38,22 @@ func NewTransaction() *Transaction {
 		ProgrammableTransaction: &ProgrammableTransaction{},
 	}
 	data.V1.GasData = &GasData{}
+	data.V1.Expiration = &TransactionExpiration{
+		None: struct{}{},
+	}
 
 	return &Transaction{
 		Data: data,
 	}
 }
 
-func (tx *Transaction) SetSigner(signer *keypair.Keypair) *Transaction {
+func (tx *Transaction) SetSigner(signer keypair.KeySigner) *Transaction {
 	tx.Signer = signer
 
 	return tx
 }
 
-func (tx *Transaction) SetSponsoredSigner(signer *keypair.Keypair) *Transaction {
+func (tx *Transaction) SetSponsoredSigner(signer keypair.KeySigner) *Transaction {
 	tx.SponsoredSigner = signer
 
 	return tx
@@ -117,6 +126,14 @@ func (tx *Transaction) SetGasBudgetIfNotSet(budget uint64) *Transaction {
 	return tx
 }
 
+// SetGasBudgetEstimation makes the transaction estimate its gas budget with a dry run
+// when no budget is set, adding safetyMarginPercent on top of the estimated cost.
+func (tx *Transaction) SetGasBudgetEstimation(safetyMarginPercent uint64) *Transaction {
+	tx.gasBudgetSafetyMargin = &safetyMarginPercent
+
+	return tx
+}
+
 func (tx *Transaction) Gas() Argument {
 	return Argument{
 		GasCoin: struct{}{},
@@ -143,16 +160,9 @@ func (tx *Transaction) MergeCoins(destination Argument, sources []Argument) Argu
 	}))
 }
 
-func (tx *Transaction) Publish(modules []model.MgoAddress, dependencies []model.MgoAddress) Argument {
-	moduleAddress := make([]model.MgoAddressBytes, len(modules))
-	for i, module := range modules {
-		v, err := ConvertMgoAddressStringToBytes(module)
-		if err != nil {
-			panic(err)
-		}
-		moduleAddress[i] = *v
-	}
-
+// Publish publishes a package from its compiled modules, as produced by
+// `mgo move build --dump-bytecode-as-base64` once base64 decoded.
+func (tx *Transaction) Publish(modules [][]byte, dependencies []model.MgoAddress) Argument {
 	dependenciesAddress := make([]model.MgoAddressBytes, len(dependencies))
 	for i, dependency := range dependencies {
 		v, err := ConvertMgoAddressStringToBytes(dependency)
@@ -163,26 +173,17 @@ func (tx *Transaction) Publish(modules []model.MgoAddress, dependencies []model.
 	}
 
 	return tx.Add(publish(Publish{
-		Modules:      moduleAddress,
+		Modules:      modules,
 		Dependencies: dependenciesAddress,
 	}))
 }
 
 func (tx *Transaction) Upgrade(
-	modules []model.MgoAddress,
+	modules [][]byte,
 	dependencies []model.MgoAddress,
 	packageId model.MgoAddress,
 	ticket Argument,
 ) Argument {
-	moduleAddress := make([]model.MgoAddressBytes, len(modules))
-	for i, module := range modules {
-		v, err := ConvertMgoAddressStringToBytes(module)
-		if err != nil {
-			panic(err)
-		}
-		moduleAddress[i] = *v
-	}
-
 	dependenciesAddress := make([]model.MgoAddressBytes, len(dependencies))
 	for i, dependency := range dependencies {
 		v, err := ConvertMgoAddressStringToBytes(dependency)
@@ -198,7 +199,7 @@ func (tx *Transaction) Upgrade(
 	}
 
 	return tx.Add(upgrade(Upgrade{
-		Modules:      moduleAddress,
+		Modules:      modules,
 		Dependencies: dependenciesAddress,
 		Package:      *packageIdBytes,
 		Ticket:       &ticket,
@@ -233,9 +234,21 @@ func (tx *Transaction) TransferObjects(objects []Argument, address Argument) Arg
 	}))
 }
 
+// MakeMoveVec creates a vector of the elements. The element type, e.g. `u64` or
+// `0x2::coin::Coin<0x2::mgo::MGO>`, may be nil when the elements are objects. An invalid
+// element type is returned as an error when the transaction is built.
 func (tx *Transaction) MakeMoveVec(typeValue *string, elements []Argument) Argument {
+	var typeTag *TypeTag
+	if typeValue != nil {
+		var err error
+		typeTag, err = ParseTypeTag(*typeValue)
+		if err != nil && tx.err == nil {
+			tx.err = err
+		}
+	}
+
 	return tx.Add(makeMoveVec(MakeMoveVec{
-		Type:     typeValue,
+		Type:     typeTag,
 		Elements: convertArgumentsToArgumentPtrs(elements),
 	}))
 }
@@ -245,6 +258,11 @@ func (tx *Transaction) Object(input any) Argument {
 	if s, ok := input.(string); ok {
 		if utils.IsValidMgoAddress(model.MgoAddress(s)) {
 			address := utils.NormalizeMgoAddress(s)
+			if index := tx.Data.V1.GetInputObjectIndex(address); index != nil {
+				return Argument{
+					Input: index,
+				}
+			}
 			addressBytes, err := ConvertMgoAddressStringToBytes(address)
 			if err != nil {
 				panic(err)
@@ -307,9 +325,12 @@ func (tx *Transaction) Object(input any) Argument {
 	panic(ErrObjectNotSupportType)
 }
 
+// Pure adds a pure input BCS encoded from its Go type. A string is encoded as an address
+// only when it is 0x followed by 1 to 64 hex digits, and as a BCS string otherwise. Use
+// UnresolvedPure to encode a value from the parameter type of the Move function instead.
 func (tx *Transaction) Pure(input any) Argument {
 	var val []byte
-	if s, ok := input.(string); ok && utils.IsValidMgoAddress(model.MgoAddress(s)) {
+	if s, ok := input.(string); ok && strings.HasPrefix(s, "0x") && utils.IsValidMgoAddress(model.MgoAddress(s)) {
 		fixedAddressBytes, err := ConvertMgoAddressStringToBytes(model.MgoAddress(s))
 		if err != nil {
 			panic(err)
@@ -333,6 +354,15 @@ func (tx *Transaction) Pure(input any) Argument {
 	return arg
 }
 
+// UnresolvedPure adds a pure input whose BCS encoding is decided when the transaction is
+// built, from the parameter type of the Move function it is passed to. Amounts of
+// SplitCoins are encoded as u64 and the recipient of TransferObjects as an address.
+func (tx *Transaction) UnresolvedPure(value any) Argument {
+	return tx.Data.V1.AddInput(CallArg{UnresolvedPure: &UnresolvedPure{
+		Value: value,
+	}})
+}
+
 func (tx *Transaction) Execute(
 	ctx context.Context,
 	options request.MgoTransactionBlockOptions,
@@ -368,7 +398,7 @@ func (tx *Transaction) ToMgoExecuteTransactionBlockRequest(
 	}
 	var signatures []string
 	if tx.SponsoredSigner != nil {
-		sponsoredMessage, err := tx.SponsoredSigner.SignTransactionBlock(&model.TxnMetaData{
+		sponsoredMessage, err := keypair.SignTransactionBlock(ctx, tx.SponsoredSigner, &model.TxnMetaData{
 			TxBytes: b64TxBytes,
 		})
 		if err != nil {
@@ -376,7 +406,7 @@ func (tx *Transaction) ToMgoExecuteTransactionBlockRequest(
 		}
 		signatures = append(signatures, sponsoredMessage.Signature)
 	}
-	message, err := tx.Signer.SignTransactionBlock(&model.TxnMetaData{
+	message, err := keypair.SignTransactionBlock(ctx, tx.Signer, &model.TxnMetaData{
 		TxBytes: b64TxBytes,
 	})
 	if err != nil {
@@ -393,6 +423,9 @@ func (tx *Transaction) ToMgoExecuteTransactionBlockRequest(
 }
 
 func (tx *Transaction) buildTransaction(ctx context.Context) (string, error) {
+	if tx.err != nil {
+		return "", tx.err
+	}
 	if tx.Signer == nil {
 		return "", ErrSignerNotSet
 	}
@@ -406,13 +439,41 @@ func (tx *Transaction) buildTransaction(ctx context.Context) (string, error) {
 			tx.SetGasPrice(rsp)
 		}
 	}
+	tx.SetSenderIfNotSet(model.MgoAddress(keypair.SignerMgoAddress(tx.Signer)))
+	if tx.Data.V1.GasData.Owner == nil {
+		tx.SetGasOwner(model.MgoAddress(keypair.SignerMgoAddress(tx.Signer)))
+	}
+
+	resolver := &moveFunctionResolver{client: tx.MgoClient}
+	if err := tx.resolveObjects(ctx, resolver); err != nil {
+		return "", err
+	}
+	if err := tx.resolvePureValues(ctx, resolver); err != nil {
+		return "", err
+	}
+
+	if tx.Data.V1.GasData.Budget == nil && tx.gasBudgetSafetyMargin != nil && tx.MgoClient != nil {
+		budget, err := tx.EstimateGasBudget(ctx, *tx.gasBudgetSafetyMargin)
+		if err != nil {
+			return "", err
+		}
+		tx.SetGasBudget(budget)
+	}
 	tx.SetGasBudgetIfNotSet(defaultGasBudget)
-	tx.SetSenderIfNotSet(model.MgoAddress(tx.Signer.MgoAddress()))
+
+	if tx.Data.V1.GasData.Payment == nil && tx.MgoClient != nil {
+		if err := tx.selectGasPayment(ctx); err != nil {
+			return "", err
+		}
+	}
 
 	return tx.build(false)
 }
 
 func (tx *Transaction) build(onlyTransactionKind bool) (string, error) {
+	if tx.err != nil {
+		return "", tx.err
+	}
 	if onlyTransactionKind {
 		bcsEncodedMsg, err := tx.Data.V1.Kind.Marshal()
 		if err != nil {
@@ -426,11 +487,14 @@ func (tx *Transaction) build(onlyTransactionKind bool) (string, error) {
 		return "", ErrSenderNotSet
 	}
 	if tx.Data.V1.GasData.Owner == nil {
-		tx.SetGasOwner(model.MgoAddress(tx.Signer.MgoAddress()))
+		tx.SetGasOwner(model.MgoAddress(keypair.SignerMgoAddress(tx.Signer)))
 	}
 	if !tx.Data.V1.GasData.IsAllSet() {
 		return "", ErrGasDataNotAllSet
 	}
+	if tx.Data.V1.Expiration == nil {
+		tx.Data.V1.Expiration = &TransactionExpiration{None: struct{}{}}
+	}
 
 	bcsEncodedMsg, err := tx.Data.Marshal()
 	if err != nil {
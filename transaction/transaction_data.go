@@ -5,6 +5,7 @@ import (
 
 	"github.com/mangonet-labs/mgo-go-sdk/bcs"
 	"github.com/mangonet-labs/mgo-go-sdk/model"
+	"github.com/mangonet-labs/mgo-go-sdk/typetag"
 )
 
 type TransactionData struct {
@@ -28,7 +29,7 @@ type TransactionDataV1 struct {
 	Kind       *TransactionKind
 	Sender     *model.MgoAddressBytes
 	GasData    *GasData
-	Expiration *TransactionExpiration `bcs:"optional" json:"expiration"`
+	Expiration *TransactionExpiration `json:"expiration"`
 }
 
 func (td *TransactionDataV1) AddCommand(command Command) (index uint16) {
@@ -54,6 +55,13 @@ func (td *TransactionDataV1) GetInputObjectIndex(address model.MgoAddress) *uint
 	}
 
 	for i, input := range td.Kind.ProgrammableTransaction.Inputs {
+		if input.UnresolvedObject != nil {
+			if input.UnresolvedObject.ObjectId.IsEqual(*addressBytes) {
+				index := uint16(i)
+				return &index
+			}
+			continue
+		}
 		if input.Object == nil {
 			continue
 		}
@@ -196,17 +204,17 @@ type MergeCoins struct {
 }
 
 type Publish struct {
-	Modules      []model.MgoAddressBytes
+	Modules      [][]byte
 	Dependencies []model.MgoAddressBytes
 }
 
 type MakeMoveVec struct {
-	Type     *string
+	Type     *TypeTag `bcs:"optional"`
 	Elements []*Argument
 }
 
 type Upgrade struct {
-	Modules      []model.MgoAddressBytes
+	Modules      [][]byte
 	Dependencies []model.MgoAddressBytes
 	Package      model.MgoAddressBytes
 	Ticket       *Argument
@@ -238,25 +246,6 @@ type SharedObjectRef struct {
 	Mutable              bool
 }
 
-type StructTag struct {
-	Address    model.MgoAddressBytes
-	Module     string
-	Name       string
-	TypeParams []*TypeTag
-}
-
-type TypeTag struct {
-	Bool    *bool
-	U8      *bool
-	U128    *bool
-	U256    *bool
-	Address *bool
-	Signer  *bool
-	Vector  *TypeTag
-	Struct  *StructTag
-	U16     *bool
-	U32     *bool
-	U64     *bool
-}
+type StructTag = typetag.StructTag
 
-func (*TypeTag) IsBcsEnum() {}
+type TypeTag = typetag.TypeTag
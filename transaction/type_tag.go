@@ -0,0 +1,22 @@
+package transaction
+
+import "github.com/mangonet-labs/mgo-go-sdk/typetag"
+
+// ErrInvalidTypeTag is returned for types that cannot be parsed or encoded.
+var ErrInvalidTypeTag = typetag.ErrInvalidTypeTag
+
+// ParseTypeTag parses a Move type such as `u64`, `vector<u8>` or
+// `0x2::coin::Coin<0x2::mgo::MGO>`. Addresses may be given in short or full form.
+func ParseTypeTag(s string) (*TypeTag, error) {
+	return typetag.ParseTypeTag(s)
+}
+
+// ParseStructTag parses a Move struct type such as `0x2::coin::Coin<0x2::mgo::MGO>`.
+func ParseStructTag(s string) (*StructTag, error) {
+	return typetag.ParseStructTag(s)
+}
+
+// ParseTypeTags parses a list of Move types, e.g. the type arguments of a Move call.
+func ParseTypeTags(types ...string) ([]TypeTag, error) {
+	return typetag.ParseTypeTags(types...)
+}
@@ -0,0 +1,410 @@
+// Package typetag parses, formats and BCS encodes Move type tags such as
+// `0x2::coin::Coin<0x2::mgo::MGO>`. It only depends on the bcs and model packages, so
+// model packages can use it without pulling in the transaction builder.
+package typetag
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mangonet-labs/mgo-go-sdk/bcs"
+	"github.com/mangonet-labs/mgo-go-sdk/model"
+)
+
+// TypeTag variant indexes as defined by the Move `TypeTag` enum.
+const (
+	typeTagBool = iota
+	typeTagU8
+	typeTagU64
+	typeTagU128
+	typeTagAddress
+	typeTagSigner
+	typeTagVector
+	typeTagStruct
+	typeTagU16
+	typeTagU32
+	typeTagU256
+)
+
+var ErrInvalidTypeTag = errors.New("invalid type tag")
+
+// StructTag is a Move struct type.
+type StructTag struct {
+	Address    model.MgoAddressBytes
+	Module     string
+	Name       string
+	TypeParams []*TypeTag
+}
+
+// TypeTag is a Move type, encoded as the Move `TypeTag` enum. Exactly one field is set.
+type TypeTag struct {
+	Bool    *bool
+	U8      *bool
+	U128    *bool
+	U256    *bool
+	Address *bool
+	Signer  *bool
+	Vector  *TypeTag
+	Struct  *StructTag
+	U16     *bool
+	U32     *bool
+	U64     *bool
+}
+
+func (*TypeTag) IsBcsEnum() {}
+
+// ParseTypeTag parses a Move type such as `u64`, `vector<u8>` or
+// `0x2::coin::Coin<0x2::mgo::MGO>`. Addresses may be given in short or full form.
+func ParseTypeTag(s string) (*TypeTag, error) {
+	p := &typeTagParser{input: s}
+	tag, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpaces(); p.pos != len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+
+	return tag, nil
+}
+
+// ParseStructTag parses a Move struct type such as `0x2::coin::Coin<0x2::mgo::MGO>`.
+func ParseStructTag(s string) (*StructTag, error) {
+	tag, err := ParseTypeTag(s)
+	if err != nil {
+		return nil, err
+	}
+	if tag.Struct == nil {
+		return nil, fmt.Errorf("%w: %q is not a struct type", ErrInvalidTypeTag, s)
+	}
+
+	return tag.Struct, nil
+}
+
+// ParseTypeTags parses a list of Move types, e.g. the type arguments of a Move call.
+func ParseTypeTags(types ...string) ([]TypeTag, error) {
+	tags := make([]TypeTag, len(types))
+	for i, s := range types {
+		tag, err := ParseTypeTag(s)
+		if err != nil {
+			return nil, err
+		}
+		tags[i] = *tag
+	}
+
+	return tags, nil
+}
+
+// String formats the type with addresses in their shortest form, e.g.
+// `0x2::coin::Coin<0x2::mgo::MGO>`, which is how the node reports types.
+func (t TypeTag) String() string {
+	return t.format(shortAddress)
+}
+
+// CanonicalString formats the type with every address in its full 32-byte form.
+func (t TypeTag) CanonicalString() string {
+	return t.format(fullAddress)
+}
+
+// String formats the struct type with addresses in their shortest form.
+func (s StructTag) String() string {
+	return s.format(shortAddress)
+}
+
+// CanonicalString formats the struct type with every address in its full 32-byte form.
+func (s StructTag) CanonicalString() string {
+	return s.format(fullAddress)
+}
+
+func (t TypeTag) format(address func(model.MgoAddressBytes) string) string {
+	switch {
+	case t.Bool != nil:
+		return "bool"
+	case t.U8 != nil:
+		return "u8"
+	case t.U16 != nil:
+		return "u16"
+	case t.U32 != nil:
+		return "u32"
+	case t.U64 != nil:
+		return "u64"
+	case t.U128 != nil:
+		return "u128"
+	case t.U256 != nil:
+		return "u256"
+	case t.Address != nil:
+		return "address"
+	case t.Signer != nil:
+		return "signer"
+	case t.Vector != nil:
+		return "vector<" + t.Vector.format(address) + ">"
+	case t.Struct != nil:
+		return t.Struct.format(address)
+	default:
+		return ""
+	}
+}
+
+func (s StructTag) format(address func(model.MgoAddressBytes) string) string {
+	var b strings.Builder
+	b.WriteString(address(s.Address))
+	b.WriteString("::")
+	b.WriteString(s.Module)
+	b.WriteString("::")
+	b.WriteString(s.Name)
+	if len(s.TypeParams) > 0 {
+		b.WriteString("<")
+		for i, typeParam := range s.TypeParams {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(typeParam.format(address))
+		}
+		b.WriteString(">")
+	}
+
+	return b.String()
+}
+
+func shortAddress(address model.MgoAddressBytes) string {
+	s := strings.TrimLeft(hex.EncodeToString(address[:]), "0")
+	if s == "" {
+		s = "0"
+	}
+
+	return "0x" + s
+}
+
+func fullAddress(address model.MgoAddressBytes) string {
+	return "0x" + hex.EncodeToString(address[:])
+}
+
+// MarshalBCS encodes the type tag as the Move `TypeTag` enum. Primitive variants carry no data.
+func (t *TypeTag) MarshalBCS() ([]byte, error) {
+	switch {
+	case t.Bool != nil:
+		return bcs.ULEB128Encode(typeTagBool), nil
+	case t.U8 != nil:
+		return bcs.ULEB128Encode(typeTagU8), nil
+	case t.U16 != nil:
+		return bcs.ULEB128Encode(typeTagU16), nil
+	case t.U32 != nil:
+		return bcs.ULEB128Encode(typeTagU32), nil
+	case t.U64 != nil:
+		return bcs.ULEB128Encode(typeTagU64), nil
+	case t.U128 != nil:
+		return bcs.ULEB128Encode(typeTagU128), nil
+	case t.U256 != nil:
+		return bcs.ULEB128Encode(typeTagU256), nil
+	case t.Address != nil:
+		return bcs.ULEB128Encode(typeTagAddress), nil
+	case t.Signer != nil:
+		return bcs.ULEB128Encode(typeTagSigner), nil
+	case t.Vector != nil:
+		inner, err := t.Vector.MarshalBCS()
+		if err != nil {
+			return nil, err
+		}
+		return append(bcs.ULEB128Encode(typeTagVector), inner...), nil
+	case t.Struct != nil:
+		inner, err := bcs.Marshal(t.Struct)
+		if err != nil {
+			return nil, err
+		}
+		return append(bcs.ULEB128Encode(typeTagStruct), inner...), nil
+	default:
+		return nil, fmt.Errorf("%w: no variant is set", ErrInvalidTypeTag)
+	}
+}
+
+// UnmarshalBCS decodes a Move `TypeTag` enum.
+func (t *TypeTag) UnmarshalBCS(r io.Reader) (int, error) {
+	variant, n, err := bcs.ULEB128Decode[int](r)
+	if err != nil {
+		return n, err
+	}
+
+	*t = TypeTag{}
+	set := true
+	switch variant {
+	case typeTagBool:
+		t.Bool = &set
+	case typeTagU8:
+		t.U8 = &set
+	case typeTagU16:
+		t.U16 = &set
+	case typeTagU32:
+		t.U32 = &set
+	case typeTagU64:
+		t.U64 = &set
+	case typeTagU128:
+		t.U128 = &set
+	case typeTagU256:
+		t.U256 = &set
+	case typeTagAddress:
+		t.Address = &set
+	case typeTagSigner:
+		t.Signer = &set
+	case typeTagVector:
+		t.Vector = &TypeTag{}
+		k, err := t.Vector.UnmarshalBCS(r)
+		return n + k, err
+	case typeTagStruct:
+		t.Struct = &StructTag{}
+		k, err := bcs.NewDecoder(r).Decode(t.Struct)
+		return n + k, err
+	default:
+		return n, fmt.Errorf("%w: unknown variant %d", ErrInvalidTypeTag, variant)
+	}
+
+	return n, nil
+}
+
+type typeTagParser struct {
+	input string
+	pos   int
+}
+
+func (p *typeTagParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %q at offset %d: %s", ErrInvalidTypeTag, p.input, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *typeTagParser) skipSpaces() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+func (p *typeTagParser) consume(token string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.input[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+
+	return false
+}
+
+func (p *typeTagParser) identifier() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+			p.pos++
+			continue
+		}
+		break
+	}
+
+	return p.input[start:p.pos]
+}
+
+func (p *typeTagParser) parseType() (*TypeTag, error) {
+	start := p.pos
+	word := p.identifier()
+	if word == "" {
+		return nil, p.errorf("expected a type")
+	}
+
+	set := true
+	switch word {
+	case "bool":
+		return &TypeTag{Bool: &set}, nil
+	case "u8":
+		return &TypeTag{U8: &set}, nil
+	case "u16":
+		return &TypeTag{U16: &set}, nil
+	case "u32":
+		return &TypeTag{U32: &set}, nil
+	case "u64":
+		return &TypeTag{U64: &set}, nil
+	case "u128":
+		return &TypeTag{U128: &set}, nil
+	case "u256":
+		return &TypeTag{U256: &set}, nil
+	case "address":
+		return &TypeTag{Address: &set}, nil
+	case "signer":
+		return &TypeTag{Signer: &set}, nil
+	case "vector":
+		if !p.consume("<") {
+			return nil, p.errorf("expected '<' after vector")
+		}
+		inner, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(">") {
+			return nil, p.errorf("expected '>'")
+		}
+		return &TypeTag{Vector: inner}, nil
+	}
+
+	p.pos = start
+	structTag, err := p.parseStruct()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypeTag{Struct: structTag}, nil
+}
+
+func (p *typeTagParser) parseStruct() (*StructTag, error) {
+	address := p.identifier()
+	hexAddress := strings.TrimPrefix(address, "0x")
+	if hexAddress == "" || len(hexAddress) > 64 {
+		return nil, p.errorf("invalid address %q", address)
+	}
+	if len(hexAddress)%2 == 1 {
+		hexAddress = "0" + hexAddress
+	}
+	decoded, err := hex.DecodeString(hexAddress)
+	if err != nil {
+		return nil, p.errorf("invalid address %q", address)
+	}
+	var addressBytes model.MgoAddressBytes
+	copy(addressBytes[32-len(decoded):], decoded)
+
+	if !p.consume("::") {
+		return nil, p.errorf("expected '::'")
+	}
+	module := p.identifier()
+	if module == "" {
+		return nil, p.errorf("expected a module name")
+	}
+	if !p.consume("::") {
+		return nil, p.errorf("expected '::'")
+	}
+	name := p.identifier()
+	if name == "" {
+		return nil, p.errorf("expected a struct name")
+	}
+
+	structTag := &StructTag{
+		Address:    addressBytes,
+		Module:     module,
+		Name:       name,
+		TypeParams: []*TypeTag{},
+	}
+	if !p.consume("<") {
+		return structTag, nil
+	}
+	for {
+		typeParam, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		structTag.TypeParams = append(structTag.TypeParams, typeParam)
+		if p.consume(",") {
+			continue
+		}
+		if p.consume(">") {
+			return structTag, nil
+		}
+		return nil, p.errorf("expected ',' or '>'")
+	}
+}
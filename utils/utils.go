@@ -12,11 +12,27 @@ func NormalizeMgoAddress(input string) model.MgoAddress {
 		addr = addr[2:]
 	}
 
-	addr = strings.Repeat("0", 64-len(addr)) + addr
+	if len(addr) < 64 {
+		addr = strings.Repeat("0", 64-len(addr)) + addr
+	}
 	return model.MgoAddress("0x" + addr)
 }
 
+// IsValidMgoAddress reports whether addr is a hex encoded address of at most 32 bytes,
+// with or without the 0x prefix. Short addresses such as 0x2 are left-padded by NormalizeMgoAddress.
 func IsValidMgoAddress(addr model.MgoAddress) bool {
-	addr = NormalizeMgoAddress(string(addr))
-	return len(addr) == 66 && strings.HasPrefix(string(addr), "0x")
+	hexAddr := strings.ToLower(string(addr))
+	if strings.HasPrefix(hexAddr, "0x") {
+		hexAddr = hexAddr[2:]
+	}
+	if len(hexAddr) == 0 || len(hexAddr) > 64 {
+		return false
+	}
+	for _, c := range hexAddr {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
 }